@@ -8,4 +8,6 @@ require (
 	github.com/jessevdk/go-flags v1.5.0
 	github.com/karrick/godirwalk v1.16.1
 	github.com/rivo/tview v0.0.0-20210624165335-29d673af0ce2
+	golang.org/x/sys v0.0.0-20210320140829-1e4c9ba3b0c4
+	golang.org/x/text v0.3.5
 )
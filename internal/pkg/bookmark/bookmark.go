@@ -0,0 +1,278 @@
+// Package bookmark manages the named bookmarks that let the user jump straight to frequently
+// used directories.
+package bookmark
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/config"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/utils"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+)
+
+const bookmarksFileName = "bookmarks.json"
+
+// Bookmark is a directory with a name.
+type Bookmark struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// Store is a collection of bookmarks that is stored as a file. It is safe for several processes
+// to use the same Store file at once.
+type Store struct {
+	path   string
+	exists func(path string) bool
+}
+
+// NewStore creates a Store that keeps its bookmarks in the specified file.
+func NewStore(path string) *Store {
+	return &Store{
+		path: path,
+		exists: func(path string) bool {
+			info, err := os.Stat(path)
+			return err == nil && info.IsDir()
+		},
+	}
+}
+
+// NewDefaultStore creates a Store in the configuration directory of ci.
+func NewDefaultStore() (*Store, error) {
+	configDir, err := config.Dir()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewStore(filepath.Join(configDir, bookmarksFileName)), nil
+}
+
+// ValidateName returns an error if the name can't be used for a bookmark. Names must not be
+// empty, and must not contain whitespace or path separators.
+func ValidateName(name string) error {
+	if name == "" {
+		return errors.New("the bookmark name must not be empty")
+	}
+
+	if strings.IndexFunc(name, unicode.IsSpace) >= 0 || strings.ContainsAny(name, `/\`) {
+		return fmt.Errorf("invalid bookmark name '%s', names must not contain whitespace or path separators", name)
+	}
+
+	return nil
+}
+
+// List returns all bookmarks ordered by name.
+func (s *Store) List() ([]Bookmark, error) {
+	return s.read()
+}
+
+// Get returns the bookmark with the name.
+func (s *Store) Get(name string) (Bookmark, error) {
+	bookmarks, err := s.read()
+	if err != nil {
+		return Bookmark{}, err
+	}
+
+	for _, bookmark := range bookmarks {
+		if bookmark.Name == name {
+			return bookmark, nil
+		}
+	}
+
+	return Bookmark{}, fmt.Errorf("no bookmark named '%s'", name)
+}
+
+// IsStale determines if the directory of the bookmark no longer exists.
+func (s *Store) IsStale(bookmark Bookmark) bool {
+	return !s.exists(bookmark.Path)
+}
+
+// Resolve returns the directory of the bookmark with the name. It returns an error if the
+// directory no longer exists.
+func (s *Store) Resolve(name string) (string, error) {
+	bookmark, err := s.Get(name)
+	if err != nil {
+		return "", err
+	}
+
+	if s.IsStale(bookmark) {
+		return "", fmt.Errorf("the directory '%s' of bookmark '%s' no longer exists", bookmark.Path, bookmark.Name)
+	}
+
+	return bookmark.Path, nil
+}
+
+// WriteList writes the name and directory of every bookmark to the writer in aligned columns,
+// and marks the bookmarks of directories that no longer exist.
+func (s *Store) WriteList(w io.Writer) error {
+	bookmarks, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, bookmark := range bookmarks {
+		var status string
+		if s.IsStale(bookmark) {
+			status = "\t(missing)"
+		}
+		if _, err = fmt.Fprintf(writer, "%s\t%s%s\n", bookmark.Name, bookmark.Path, status); err != nil {
+			return err
+		}
+	}
+
+	return writer.Flush()
+}
+
+// Add saves a bookmark to the directory. An existing bookmark with the same name is only
+// replaced if overwrite is set.
+func (s *Store) Add(name, path string, overwrite bool) error {
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+
+	return s.update(func(bookmarks []Bookmark) ([]Bookmark, error) {
+		for i, bookmark := range bookmarks {
+			if bookmark.Name == name {
+				if !overwrite {
+					return nil, fmt.Errorf("a bookmark named '%s' already exists for '%s'", name, bookmark.Path)
+				}
+				bookmarks[i].Path = path
+				return bookmarks, nil
+			}
+		}
+
+		return append(bookmarks, Bookmark{Name: name, Path: path}), nil
+	})
+}
+
+// Remove deletes the bookmark with the name.
+func (s *Store) Remove(name string) error {
+	return s.update(func(bookmarks []Bookmark) ([]Bookmark, error) {
+		for i, bookmark := range bookmarks {
+			if bookmark.Name == name {
+				return append(bookmarks[:i], bookmarks[i+1:]...), nil
+			}
+		}
+
+		return nil, fmt.Errorf("no bookmark named '%s'", name)
+	})
+}
+
+// Import adds the bookmarks that were written by Export to the Store, replacing existing
+// bookmarks with the same names. It returns the number of imported bookmarks.
+func (s *Store) Import(r io.Reader) (int, error) {
+	var imported []Bookmark
+	if err := json.NewDecoder(r).Decode(&imported); err != nil {
+		return 0, fmt.Errorf("unable to read the bookmarks to import: %w", err)
+	}
+
+	for _, bookmark := range imported {
+		if err := ValidateName(bookmark.Name); err != nil {
+			return 0, err
+		}
+	}
+
+	err := s.update(func(bookmarks []Bookmark) ([]Bookmark, error) {
+		for _, bookmark := range imported {
+			replaced := false
+			for i := range bookmarks {
+				if bookmarks[i].Name == bookmark.Name {
+					bookmarks[i].Path = bookmark.Path
+					replaced = true
+				}
+			}
+			if !replaced {
+				bookmarks = append(bookmarks, bookmark)
+			}
+		}
+
+		return bookmarks, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(imported), nil
+}
+
+// Export writes all bookmarks to the writer in a format that Import can read.
+func (s *Store) Export(w io.Writer) error {
+	bookmarks, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	if bookmarks == nil {
+		bookmarks = []Bookmark{}
+	}
+
+	data, err := json.MarshalIndent(bookmarks, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return err
+}
+
+// update locks the bookmarks file, applies the change to the bookmarks it contains, and saves
+// the result.
+func (s *Store) update(change func(bookmarks []Bookmark) ([]Bookmark, error)) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("unable to create the bookmarks directory: %w", err)
+	}
+
+	release, err := utils.LockFile(s.path)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	bookmarks, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	if bookmarks, err = change(bookmarks); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(bookmarks, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return utils.WriteFileAtomic(s.path, data)
+}
+
+// read loads the bookmarks from the file and orders them by name. A missing file contains
+// no bookmarks.
+func (s *Store) read() ([]Bookmark, error) {
+	data, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to read the bookmarks: %w", err)
+	}
+
+	var bookmarks []Bookmark
+	if err = json.Unmarshal(data, &bookmarks); err != nil {
+		return nil, fmt.Errorf("the bookmarks file '%s' is corrupt: %w", s.path, err)
+	}
+
+	sort.SliceStable(bookmarks, func(i, j int) bool {
+		return bookmarks[i].Name < bookmarks[j].Name
+	})
+
+	return bookmarks, nil
+}
@@ -0,0 +1,198 @@
+package bookmark
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func getTestStore(t *testing.T, existing ...string) *Store {
+	store := NewStore(filepath.Join(t.TempDir(), bookmarksFileName))
+	store.exists = func(path string) bool {
+		for _, e := range existing {
+			if e == path {
+				return true
+			}
+		}
+		return false
+	}
+	return store
+}
+
+func Test_Store_List_ReturnsNothingWithoutBookmarksFile(t *testing.T) {
+	bookmarks, err := getTestStore(t).List()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(bookmarks) != 0 {
+		t.Errorf("expected no bookmarks, got %v", bookmarks)
+	}
+}
+
+func Test_Store_Add_ListsBookmarksByName(t *testing.T) {
+	store := getTestStore(t)
+
+	_ = store.Add("web", "/src/web", false)
+	_ = store.Add("api", "/src/api", false)
+
+	bookmarks, _ := store.List()
+	if len(bookmarks) != 2 || bookmarks[0].Name != "api" || bookmarks[1].Name != "web" {
+		t.Errorf("expected the bookmarks api and web, got %v", bookmarks)
+	}
+}
+
+func Test_Store_Add_DoesNotReplaceExistingBookmarkUnlessOverwriting(t *testing.T) {
+	store := getTestStore(t)
+
+	_ = store.Add("api", "/src/api", false)
+
+	if err := store.Add("api", "/src/other", false); err == nil {
+		t.Error("expected an error when adding a bookmark with an existing name")
+	}
+
+	if err := store.Add("api", "/src/other", true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if bookmark, _ := store.Get("api"); bookmark.Path != "/src/other" {
+		t.Errorf("expected the bookmark to be replaced, got %v", bookmark)
+	}
+}
+
+func Test_Store_Add_RejectsInvalidNames(t *testing.T) {
+	store := getTestStore(t)
+
+	for _, name := range []string{"", "my api", "src/api"} {
+		if err := store.Add(name, "/src/api", false); err == nil {
+			t.Errorf("expected an error for the name '%s'", name)
+		}
+	}
+}
+
+func Test_Store_Remove_DeletesBookmark(t *testing.T) {
+	store := getTestStore(t)
+
+	_ = store.Add("api", "/src/api", false)
+
+	if err := store.Remove("api"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := store.Get("api"); err == nil {
+		t.Error("expected the bookmark to be removed")
+	}
+
+	if err := store.Remove("api"); err == nil {
+		t.Error("expected an error when removing a missing bookmark")
+	}
+}
+
+func Test_Store_IsStale_DetectsMissingDirectories(t *testing.T) {
+	store := getTestStore(t, "/src/api")
+
+	if store.IsStale(Bookmark{Name: "api", Path: "/src/api"}) {
+		t.Error("did not expect an existing directory to be stale")
+	}
+
+	if !store.IsStale(Bookmark{Name: "web", Path: "/src/web"}) {
+		t.Error("expected a missing directory to be stale")
+	}
+}
+
+func Test_Store_Resolve_ReturnsDirectoryOfBookmark(t *testing.T) {
+	store := getTestStore(t, "/src/api")
+	_ = store.Add("api", "/src/api", false)
+
+	if path, err := store.Resolve("api"); err != nil || path != "/src/api" {
+		t.Errorf("expected '/src/api', got '%s', %v", path, err)
+	}
+}
+
+func Test_Store_Resolve_FailsForMissingDirectory(t *testing.T) {
+	store := getTestStore(t)
+	_ = store.Add("old", "/src/old", false)
+
+	if _, err := store.Resolve("old"); err == nil {
+		t.Error("expected an error for a bookmark whose directory no longer exists")
+	}
+	if _, err := store.Resolve("web"); err == nil {
+		t.Error("expected an error for a bookmark that does not exist")
+	}
+}
+
+func Test_Store_WriteList_MarksMissingDirectories(t *testing.T) {
+	store := getTestStore(t, "/src/api")
+	_ = store.Add("api", "/src/api", false)
+	_ = store.Add("old", "/src/old", false)
+
+	var buffer bytes.Buffer
+	if err := store.WriteList(&buffer); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "api  /src/api\nold  /src/old  (missing)\n"
+	if buffer.String() != expected {
+		t.Errorf("expected '%s', got '%s'", expected, buffer.String())
+	}
+}
+
+func Test_Store_Import_RestoresExportedBookmarks(t *testing.T) {
+	source := getTestStore(t)
+	_ = source.Add("api", "/src/api", false)
+	_ = source.Add("web", "/src/web", false)
+
+	var exported bytes.Buffer
+	if err := source.Export(&exported); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	target := getTestStore(t)
+	_ = target.Add("api", "/old/api", false)
+	_ = target.Add("docs", "/src/docs", false)
+
+	count, err := target.Import(&exported)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if count != 2 {
+		t.Errorf("expected 2 imported bookmarks, got %d", count)
+	}
+
+	bookmarks, _ := target.List()
+	expected := []Bookmark{{"api", "/src/api"}, {"docs", "/src/docs"}, {"web", "/src/web"}}
+	if len(bookmarks) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, bookmarks)
+	}
+	for i := range expected {
+		if bookmarks[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected, bookmarks)
+		}
+	}
+}
+
+func Test_Store_Import_RejectsInvalidData(t *testing.T) {
+	store := getTestStore(t)
+
+	if _, err := store.Import(strings.NewReader(`{"name": "api"}`)); err == nil {
+		t.Error("expected an error for data that is not a list of bookmarks")
+	}
+
+	if _, err := store.Import(strings.NewReader(`[{"name": "my api", "path": "/src/api"}]`)); err == nil {
+		t.Error("expected an error for an invalid bookmark name")
+	}
+}
+
+func Test_Store_Export_WritesEmptyListWithoutBookmarks(t *testing.T) {
+	var exported bytes.Buffer
+
+	if err := getTestStore(t).Export(&exported); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if exported.String() != "[]\n" {
+		t.Errorf("expected an empty list, got '%s'", exported.String())
+	}
+}
@@ -0,0 +1,483 @@
+// Package config loads and validates the user configuration file of ci.
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	EnvConfigFile  = "CI_CONFIG"
+	configDirName  = "ci"
+	configFileName = "config.json"
+)
+
+const (
+	FilterMethodBeginsWith = "begins-with"
+	FilterMethodEndsWith   = "ends-with"
+	FilterMethodContains   = "contains"
+	FilterMethodGlob       = "glob"
+	FilterMethodFuzzy      = "fuzzy"
+	FilterMethodRegex      = "regex"
+)
+
+const (
+	SortModeName     = "name"
+	SortModeNatural  = "natural"
+	SortModeModTime  = "mtime"
+	SortModeSize     = "size"
+	SortModeFrecency = "frecency"
+)
+
+const (
+	InfoColumnMode        = "mode"
+	InfoColumnPermissions = "permissions"
+	InfoColumnOwner       = "owner"
+	InfoColumnGroup       = "group"
+	InfoColumnLinks       = "links"
+	InfoColumnInode       = "inode"
+	InfoColumnName        = "name"
+	InfoColumnSize        = "size"
+	InfoColumnBytes       = "bytes"
+	InfoColumnModified    = "modified"
+	InfoColumnCreated     = "created"
+	InfoColumnTarget      = "target"
+)
+
+// TimeFormatRelative formats the times of the directory info relative to now, such as 3h ago.
+const TimeFormatRelative = "relative"
+
+const (
+	LayoutList    = "list"
+	LayoutTree    = "tree"
+	LayoutColumns = "columns"
+)
+
+// FilterMethods lists the valid values of the filter method setting in the order that they
+// appear in the filter dialog.
+var FilterMethods = []string{
+	FilterMethodBeginsWith,
+	FilterMethodEndsWith,
+	FilterMethodContains,
+	FilterMethodGlob,
+	FilterMethodFuzzy,
+	FilterMethodRegex,
+}
+
+// Layouts lists the valid values of the layout setting.
+var Layouts = []string{
+	LayoutList,
+	LayoutTree,
+	LayoutColumns,
+}
+
+// InfoColumns lists the valid columns of the directory info table.
+var InfoColumns = []string{
+	InfoColumnMode,
+	InfoColumnPermissions,
+	InfoColumnOwner,
+	InfoColumnGroup,
+	InfoColumnLinks,
+	InfoColumnInode,
+	InfoColumnName,
+	InfoColumnSize,
+	InfoColumnBytes,
+	InfoColumnModified,
+	InfoColumnCreated,
+	InfoColumnTarget,
+}
+
+// SortModes lists the valid values of the sort mode setting in the order that they are cycled
+// through.
+var SortModes = []string{
+	SortModeName,
+	SortModeNatural,
+	SortModeModTime,
+	SortModeSize,
+	SortModeFrecency,
+}
+
+// SortConfig defines the order of the directory list. Collate compares names with the
+// Unicode collation rules of the user's locale.
+type SortConfig struct {
+	Mode    string `json:"mode"`
+	Reverse bool   `json:"reverse"`
+	Collate bool   `json:"collate"`
+}
+
+// ColorConfig defines the colors of the user interface. Colors are either W3C color names
+// or hex values in the #rrggbb format.
+type ColorConfig struct {
+	AppTitle           string `json:"appTitle"`
+	Title              string `json:"title"`
+	Border             string `json:"border"`
+	Text               string `json:"text"`
+	SelectedText       string `json:"selectedText"`
+	SelectedBackground string `json:"selectedBackground"`
+	ScrollBar          string `json:"scrollBar"`
+	ScrollThumb        string `json:"scrollThumb"`
+	File               string `json:"file"`
+}
+
+// PaneConfig defines the proportional widths of the directory list and the details pane.
+type PaneConfig struct {
+	List    int `json:"list"`
+	Details int `json:"details"`
+}
+
+// IgnoreConfig defines which directories are left out of the directory list. Patterns use the
+// gitignore format and apply in addition to the .gitignore and .ciignore files.
+type IgnoreConfig struct {
+	Enabled  bool     `json:"enabled"`
+	Patterns []string `json:"patterns"`
+}
+
+// SearchConfig defines how deep the recursive search walks below the current directory.
+type SearchConfig struct {
+	MaxDepth int `json:"maxDepth"`
+}
+
+// TypeAheadConfig defines how typing the name of a directory selects it. The timeout is the
+// number of milliseconds after which the typed characters are forgotten, and 0 disables
+// type-ahead. With altShortcuts, every character is typed ahead and the actions that are
+// bound to single characters are triggered with Alt and the character instead.
+type TypeAheadConfig struct {
+	Timeout      int  `json:"timeout"`
+	AltShortcuts bool `json:"altShortcuts"`
+}
+
+// PreviewConfig defines how much of a file the details pane previews, in kilobytes.
+type PreviewConfig struct {
+	MaxSize int `json:"maxSize"`
+}
+
+// InfoConfig defines the columns of the directory info table in the details pane. The time
+// format is either relative, or a layout of the Go time package such as 2006-01-02 15:04.
+// TotalSize calculates the size of the whole directory above the table, and GitStatus
+// summarizes the Git repository that contains the directory.
+type InfoConfig struct {
+	Columns    []string `json:"columns"`
+	TimeFormat string   `json:"timeFormat"`
+	TotalSize  bool     `json:"totalSize"`
+	GitStatus  bool     `json:"gitStatus"`
+}
+
+// Config stores the user preferences of ci.
+type Config struct {
+	FilterMethod string            `json:"filterMethod"`
+	Layout       string            `json:"layout"`
+	IgnoreCase   bool              `json:"ignoreCase"`
+	Sort         SortConfig        `json:"sort"`
+	ShowHidden   bool              `json:"showHidden"`
+	ShowFiles    bool              `json:"showFiles"`
+	Ignore       IgnoreConfig      `json:"ignore"`
+	Search       SearchConfig      `json:"search"`
+	TypeAhead    TypeAheadConfig   `json:"typeAhead"`
+	Preview      PreviewConfig     `json:"preview"`
+	Info         InfoConfig        `json:"info"`
+	Colors       ColorConfig       `json:"colors"`
+	Panes        PaneConfig        `json:"panes"`
+	KeyBindings  map[string]string `json:"keyBindings"`
+	keyMap       map[string]KeyBinding
+}
+
+// Default returns the configuration that ci uses when no configuration file exists.
+func Default() *Config {
+	c := &Config{
+		FilterMethod: FilterMethodBeginsWith,
+		Layout:       LayoutList,
+		Sort: SortConfig{
+			Mode:    SortModeName,
+			Reverse: false,
+		},
+		ShowHidden: true,
+		Ignore: IgnoreConfig{
+			Enabled:  true,
+			Patterns: []string{"node_modules", "target", "__pycache__"},
+		},
+		Search: SearchConfig{
+			MaxDepth: 5,
+		},
+		TypeAhead: TypeAheadConfig{
+			Timeout: 1000,
+		},
+		Preview: PreviewConfig{
+			MaxSize: 16,
+		},
+		Info: InfoConfig{
+			Columns:    []string{InfoColumnMode, InfoColumnName, InfoColumnModified, InfoColumnBytes},
+			TimeFormat: "2006-01-02 3:04 PM",
+			TotalSize:  true,
+			GitStatus:  true,
+		},
+		Colors: ColorConfig{
+			AppTitle:           "green",
+			Title:              "white",
+			Border:             "white",
+			Text:               "white",
+			SelectedText:       "black",
+			SelectedBackground: "white",
+			ScrollBar:          "lightgray",
+			ScrollThumb:        "yellow",
+			File:               "lightskyblue",
+		},
+		Panes: PaneConfig{
+			List:    1,
+			Details: 2,
+		},
+		KeyBindings: DefaultKeyBindings(),
+	}
+
+	// Note: The default configuration is always valid.
+	_ = c.validate(nil)
+
+	return c
+}
+
+// Dir returns the directory that contains the configuration of ci. It honours the
+// XDG_CONFIG_HOME environment variable on every platform.
+func Dir() (string, error) {
+	if xdgConfigHome := os.Getenv("XDG_CONFIG_HOME"); filepath.IsAbs(xdgConfigHome) {
+		return filepath.Join(xdgConfigHome, configDirName), nil
+	}
+
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, configDirName), nil
+}
+
+// DataDir returns the directory that contains the data files of ci, such as the list of
+// recently visited directories. It honours the XDG_DATA_HOME environment variable on every
+// platform and defaults to ~/.local/share/ci.
+func DataDir() (string, error) {
+	if xdgDataHome := os.Getenv("XDG_DATA_HOME"); filepath.IsAbs(xdgDataHome) {
+		return filepath.Join(xdgDataHome, configDirName), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".local", "share", configDirName), nil
+}
+
+// Path returns the location of the configuration file. A non-empty override takes precedence
+// over the CI_CONFIG environment variable, which takes precedence over the default location.
+// The returned flag indicates whether the location was chosen explicitly by the user.
+func Path(override string) (path string, explicit bool, err error) {
+	if override != "" {
+		return override, true, nil
+	}
+
+	if envPath := os.Getenv(EnvConfigFile); envPath != "" {
+		return envPath, true, nil
+	}
+
+	dir, err := Dir()
+	if err != nil {
+		return "", false, err
+	}
+
+	return filepath.Join(dir, configFileName), false, nil
+}
+
+// Load reads the configuration file at the location determined by Path. The default
+// configuration is returned if the file does not exist at the default location.
+func Load(override string) (*Config, error) {
+	path, explicit, err := Path(override)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) && !explicit {
+			return Default(), nil
+		}
+
+		return nil, fmt.Errorf("unable to read the configuration file: %w", err)
+	}
+
+	return Parse(path, data)
+}
+
+// Parse decodes configuration data and validates it. Settings that are absent from the data
+// retain their default values. The file name is only used to describe errors.
+func Parse(fileName string, data []byte) (*Config, error) {
+	c := Default()
+
+	positions, err := indexKeys(data)
+	if err != nil {
+		return nil, newErrorAtOffset(fileName, data, err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if err = decoder.Decode(c); err != nil {
+		return nil, newErrorAtOffset(fileName, data, err)
+	}
+
+	for _, key := range positions.keys {
+		if !isKnownKey(key.path) {
+			return nil, &Error{
+				File: fileName,
+				Line: lineAt(data, key.offset),
+				Err:  fmt.Errorf("unknown setting '%s'", strings.Join(key.path, ".")),
+			}
+		}
+	}
+
+	if err = c.validate(func(path ...string) int {
+		return lineAt(data, positions.offsetOf(path...))
+	}); err != nil {
+		if cErr, isConfigError := err.(*Error); isConfigError {
+			cErr.File = fileName
+		}
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// validate ensures that all settings have valid values. The lineOf function reports the line of
+// a setting in the configuration file so that errors can point to it.
+func (c *Config) validate(lineOf func(path ...string) int) error {
+	if lineOf == nil {
+		lineOf = func(path ...string) int { return 0 }
+	}
+
+	invalid := func(err error, path ...string) error {
+		return &Error{Line: lineOf(path...), Err: err}
+	}
+
+	if !contains(FilterMethods, c.FilterMethod) {
+		return invalid(
+			fmt.Errorf("invalid filter method '%s', expected one of: %s", c.FilterMethod, strings.Join(FilterMethods, ", ")),
+			"filterMethod")
+	}
+
+	if !contains(Layouts, c.Layout) {
+		return invalid(
+			fmt.Errorf("invalid layout '%s', expected one of: %s", c.Layout, strings.Join(Layouts, ", ")),
+			"layout")
+	}
+
+	if !contains(SortModes, c.Sort.Mode) {
+		return invalid(
+			fmt.Errorf("invalid sort mode '%s', expected one of: %s", c.Sort.Mode, strings.Join(SortModes, ", ")),
+			"sort", "mode")
+	}
+
+	for _, pattern := range c.Ignore.Patterns {
+		for _, segment := range strings.Split(pattern, "/") {
+			if _, err := filepath.Match(segment, ""); err != nil {
+				return invalid(fmt.Errorf("invalid ignore pattern '%s': %w", pattern, err), "ignore", "patterns")
+			}
+		}
+	}
+
+	if c.Search.MaxDepth < 1 {
+		return invalid(errors.New("the search depth must be at least 1"), "search", "maxDepth")
+	}
+
+	if c.TypeAhead.Timeout < 0 {
+		return invalid(errors.New("the type-ahead timeout must not be negative"), "typeAhead", "timeout")
+	}
+
+	if c.Preview.MaxSize < 1 {
+		return invalid(errors.New("the preview size must be at least 1 KB"), "preview", "maxSize")
+	}
+
+	if len(c.Info.Columns) == 0 {
+		return invalid(errors.New("the directory info must have at least one column"), "info", "columns")
+	}
+
+	for _, column := range c.Info.Columns {
+		if !contains(InfoColumns, column) {
+			return invalid(
+				fmt.Errorf("invalid info column '%s', expected one of: %s", column, strings.Join(InfoColumns, ", ")),
+				"info", "columns")
+		}
+	}
+
+	if c.Info.TimeFormat == "" {
+		return invalid(errors.New("the time format must not be empty"), "info", "timeFormat")
+	}
+
+	colors := map[string]string{
+		"appTitle":           c.Colors.AppTitle,
+		"title":              c.Colors.Title,
+		"border":             c.Colors.Border,
+		"text":               c.Colors.Text,
+		"selectedText":       c.Colors.SelectedText,
+		"selectedBackground": c.Colors.SelectedBackground,
+		"scrollBar":          c.Colors.ScrollBar,
+		"scrollThumb":        c.Colors.ScrollThumb,
+		"file":               c.Colors.File,
+	}
+	for _, name := range sortedKeys(colors) {
+		if !IsValidColor(colors[name]) {
+			return invalid(fmt.Errorf("invalid color '%s' for '%s'", colors[name], name), "colors", name)
+		}
+	}
+
+	if c.Panes.List < 1 {
+		return invalid(errors.New("the list pane ratio must be at least 1"), "panes", "list")
+	}
+
+	if c.Panes.Details < 1 {
+		return invalid(errors.New("the details pane ratio must be at least 1"), "panes", "details")
+	}
+
+	bindings := DefaultKeyBindings()
+	for action, key := range c.KeyBindings {
+		bindings[action] = key
+	}
+
+	keyMap := make(map[string]KeyBinding)
+	usedKeys := make(map[KeyBinding]string)
+	for _, action := range sortedKeys(bindings) {
+		if _, isAction := defaultKeyBindings[action]; !isAction {
+			return invalid(fmt.Errorf("unknown key binding action '%s'", action), "keyBindings", action)
+		}
+
+		binding, err := ParseKey(bindings[action])
+		if err != nil {
+			return invalid(fmt.Errorf("invalid key for action '%s': %w", action, err), "keyBindings", action)
+		}
+
+		if other, isUsed := usedKeys[binding]; isUsed {
+			return invalid(
+				fmt.Errorf("the key '%s' is bound to both '%s' and '%s'", bindings[action], other, action),
+				"keyBindings", action)
+		}
+
+		usedKeys[binding] = action
+		keyMap[action] = binding
+	}
+
+	c.KeyBindings = bindings
+	c.keyMap = keyMap
+
+	return nil
+}
+
+// GetKeyBinding returns the key that triggers the specified action.
+func (c *Config) GetKeyBinding(action string) KeyBinding {
+	if binding, exists := c.keyMap[action]; exists {
+		return binding
+	}
+
+	binding, _ := ParseKey(defaultKeyBindings[action])
+	return binding
+}
@@ -0,0 +1,237 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_Parse_RetainsDefaultsForAbsentSettings(t *testing.T) {
+	c, err := Parse("config.json", []byte(`{"showHidden": false}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.ShowHidden {
+		t.Error("Expected hidden directories to be hidden")
+	}
+
+	if c.FilterMethod != FilterMethodBeginsWith {
+		t.Errorf("Expected the filter method to default to '%s', got '%s' instead", FilterMethodBeginsWith, c.FilterMethod)
+	}
+
+	if c.Panes.List != 1 || c.Panes.Details != 2 {
+		t.Errorf("Expected the default pane ratios of 1:2, got %d:%d instead", c.Panes.List, c.Panes.Details)
+	}
+}
+
+func Test_Parse_OverridesDefaultKeyBindings(t *testing.T) {
+	c, err := Parse("config.json", []byte(`{"keyBindings": {"quit": "Ctrl+Q", "filter": "/"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if binding := c.GetKeyBinding(ActionQuit); binding.String() != "Ctrl+Q" {
+		t.Errorf("Expected the quit action to be bound to 'Ctrl+Q', got '%s' instead", binding)
+	}
+
+	if binding := c.GetKeyBinding(ActionFilter); binding.ShortcutRune() != '/' {
+		t.Errorf("Expected the filter action to be bound to '/', got '%s' instead", binding)
+	}
+
+	if binding := c.GetKeyBinding(ActionHelp); binding.ShortcutRune() != 'h' {
+		t.Errorf("Expected the help action to keep its default key 'h', got '%s' instead", binding)
+	}
+}
+
+func Test_Parse_ReportsLineNumberOfInvalidSettings(t *testing.T) {
+	testCases := map[string]struct {
+		data         string
+		expectedLine int
+		expectedText string
+	}{
+		"SyntaxError": {
+			data:         "{\n  \"showHidden\": true\n  \"filterMethod\": \"contains\"\n}",
+			expectedLine: 3,
+			expectedText: "invalid character",
+		},
+		"TypeError": {
+			data:         "{\n  \"sort\": {\n    \"reverse\": \"yes\"\n  }\n}",
+			expectedLine: 3,
+			expectedText: "sort.reverse",
+		},
+		"UnknownSetting": {
+			data:         "{\n  \"colors\": {\n    \"title\": \"red\",\n    \"background\": \"blue\"\n  }\n}",
+			expectedLine: 4,
+			expectedText: "colors.background",
+		},
+		"InvalidFilterMethod": {
+			data:         "{\n\n  \"filterMethod\": \"sideways\"\n}",
+			expectedLine: 3,
+			expectedText: "sideways",
+		},
+		"InvalidLayout": {
+			data:         "{\n  \"filterMethod\": \"fuzzy\",\n  \"layout\": \"grid\"\n}",
+			expectedLine: 3,
+			expectedText: "grid",
+		},
+		"InvalidColor": {
+			data:         "{\n  \"colors\": {\n    \"border\": \"not-a-color\"\n  }\n}",
+			expectedLine: 3,
+			expectedText: "not-a-color",
+		},
+		"InvalidPaneRatio": {
+			data:         "{\n  \"panes\": {\"list\": 1,\n    \"details\": 0}\n}",
+			expectedLine: 3,
+			expectedText: "details",
+		},
+		"InvalidIgnorePattern": {
+			data:         "{\n  \"ignore\": {\n    \"patterns\": [\"build\", \"[a-\"]\n  }\n}",
+			expectedLine: 3,
+			expectedText: "[a-",
+		},
+		"InvalidSearchDepth": {
+			data:         "{\n  \"search\": {\n    \"maxDepth\": 0\n  }\n}",
+			expectedLine: 3,
+			expectedText: "search depth",
+		},
+		"NegativeTypeAheadTimeout": {
+			data:         "{\n  \"typeAhead\": {\n    \"timeout\": -1\n  }\n}",
+			expectedLine: 3,
+			expectedText: "type-ahead timeout",
+		},
+		"InvalidPreviewSize": {
+			data:         "{\n  \"preview\": {\n    \"maxSize\": 0\n  }\n}",
+			expectedLine: 3,
+			expectedText: "preview size",
+		},
+		"UnknownInfoColumn": {
+			data:         "{\n  \"info\": {\n    \"columns\": [\"name\", \"colour\"]\n  }\n}",
+			expectedLine: 3,
+			expectedText: "colour",
+		},
+		"UnknownAction": {
+			data:         "{\n  \"keyBindings\": {\n    \"explode\": \"x\"\n  }\n}",
+			expectedLine: 3,
+			expectedText: "explode",
+		},
+		"DuplicateKey": {
+			data:         "{\n  \"keyBindings\": {\n    \"quit\": \"f\"\n  }\n}",
+			expectedLine: 3,
+			expectedText: "bound to both",
+		},
+	}
+
+	for name, testCase := range testCases {
+		_, err := Parse("config.json", []byte(testCase.data))
+
+		var cErr *Error
+		if !errors.As(err, &cErr) {
+			t.Errorf("%s: Expected an error of type Error, got '%v' instead", name, err)
+			continue
+		}
+
+		if cErr.Line != testCase.expectedLine {
+			t.Errorf("%s: Expected the error to be on line %d, got line %d instead: %v", name, testCase.expectedLine, cErr.Line, cErr)
+		}
+
+		if !strings.Contains(cErr.Error(), testCase.expectedText) {
+			t.Errorf("%s: Expected the error to contain '%s', got '%v' instead", name, testCase.expectedText, cErr)
+		}
+
+		if !strings.HasPrefix(cErr.Error(), "config.json:") {
+			t.Errorf("%s: Expected the error to begin with the file name, got '%v' instead", name, cErr)
+		}
+	}
+}
+
+func Test_Load_ReturnsDefaultsWhenDefaultFileDoesNotExist(t *testing.T) {
+	setEnvForTest(t, "XDG_CONFIG_HOME", t.TempDir())
+	setEnvForTest(t, EnvConfigFile, "")
+
+	c, err := Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.FilterMethod != Default().FilterMethod {
+		t.Errorf("Expected the default configuration, got '%+v' instead", c)
+	}
+}
+
+func Test_Load_ReturnsErrorWhenExplicitFileDoesNotExist(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Expected an error for a missing configuration file")
+	}
+}
+
+func Test_Load_PrefersOverrideToEnvironmentToDefaultLocation(t *testing.T) {
+	xdgDir := t.TempDir()
+	writeFileForTest(t, filepath.Join(xdgDir, "ci", "config.json"), `{"filterMethod": "contains"}`)
+	envFile := filepath.Join(t.TempDir(), "env.json")
+	writeFileForTest(t, envFile, `{"filterMethod": "ends-with"}`)
+	overrideFile := filepath.Join(t.TempDir(), "override.json")
+	writeFileForTest(t, overrideFile, `{"filterMethod": "glob"}`)
+
+	setEnvForTest(t, "XDG_CONFIG_HOME", xdgDir)
+	setEnvForTest(t, EnvConfigFile, "")
+
+	expectMethod := func(override, expected string) {
+		c, err := Load(override)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if c.FilterMethod != expected {
+			t.Errorf("Expected the filter method to be '%s', got '%s' instead", expected, c.FilterMethod)
+		}
+	}
+
+	expectMethod("", FilterMethodContains)
+
+	setEnvForTest(t, EnvConfigFile, envFile)
+	expectMethod("", FilterMethodEndsWith)
+	expectMethod(overrideFile, FilterMethodGlob)
+}
+
+func setEnvForTest(t *testing.T, key, value string) {
+	original, isSet := os.LookupEnv(key)
+	if err := os.Setenv(key, value); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if isSet {
+			_ = os.Setenv(key, original)
+		} else {
+			_ = os.Unsetenv(key)
+		}
+	})
+}
+
+func writeFileForTest(t *testing.T, path, content string) {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_Parse_AcceptsRegexFilterMethodAndIgnoreCase(t *testing.T) {
+	c, err := Parse("config.json", []byte(`{"filterMethod": "regex", "ignoreCase": true}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.FilterMethod != FilterMethodRegex {
+		t.Errorf("Expected the filter method to be '%s', got '%s' instead", FilterMethodRegex, c.FilterMethod)
+	}
+
+	if !c.IgnoreCase {
+		t.Error("Expected the filter to ignore case")
+	}
+}
@@ -0,0 +1,27 @@
+package config
+
+import "fmt"
+
+// Error represents a problem with the contents of the configuration file.
+type Error struct {
+	File string
+	Line int
+	Err  error
+}
+
+// Error returns the message for this error, prefixed with its location when it is known.
+func (e *Error) Error() string {
+	switch {
+	case e.File != "" && e.Line > 0:
+		return fmt.Sprintf("%s:%d: %v", e.File, e.Line, e.Err)
+	case e.File != "":
+		return fmt.Sprintf("%s: %v", e.File, e.Err)
+	default:
+		return e.Err.Error()
+	}
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
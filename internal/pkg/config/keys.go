@@ -0,0 +1,209 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gdamore/tcell/v2"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	ActionEnterDirectory = "enterDirectory"
+	ActionFilter         = "filter"
+	ActionHelp           = "help"
+	ActionQuit           = "quit"
+	ActionRecent         = "recent"
+	ActionBookmark       = "bookmark"
+	ActionBookmarks      = "bookmarks"
+	ActionBack           = "back"
+	ActionForward        = "forward"
+	ActionHistory        = "history"
+	ActionLiveFilter     = "liveFilter"
+	ActionToggleHidden   = "toggleHidden"
+	ActionToggleIgnored  = "toggleIgnored"
+	ActionCycleSort      = "cycleSort"
+	ActionReverseSort    = "reverseSort"
+	ActionSearch         = "search"
+	ActionToggleTree     = "toggleTree"
+	ActionPathEntry      = "pathEntry"
+	ActionToggleReadme   = "toggleReadme"
+)
+
+// defaultKeyBindings maps every action that can be bound to a key to its default key.
+var defaultKeyBindings = map[string]string{
+	ActionEnterDirectory: "e",
+	ActionFilter:         "f",
+	ActionHelp:           "h",
+	ActionQuit:           "q",
+	ActionRecent:         "r",
+	ActionBookmark:       "b",
+	ActionBookmarks:      "B",
+	ActionBack:           "[",
+	ActionForward:        "]",
+	ActionHistory:        "H",
+	ActionLiveFilter:     "s",
+	ActionToggleHidden:   ".",
+	ActionToggleIgnored:  "i",
+	ActionCycleSort:      "o",
+	ActionReverseSort:    "O",
+	ActionSearch:         "F",
+	ActionToggleTree:     "t",
+	ActionPathEntry:      ":",
+	ActionToggleReadme:   "R",
+}
+
+// DefaultKeyBindings returns a copy of the default key of every action.
+func DefaultKeyBindings() map[string]string {
+	bindings := make(map[string]string)
+	for action, key := range defaultKeyBindings {
+		bindings[action] = key
+	}
+
+	return bindings
+}
+
+// KeyBinding is a key combination that triggers an action.
+type KeyBinding struct {
+	Key       tcell.Key
+	Rune      rune
+	Modifiers tcell.ModMask
+}
+
+// ParseKey converts the text representation of a key combination to a KeyBinding. Keys are
+// either a single character such as "q" or a tcell key name such as "F1", "PgDn" or "Left",
+// optionally prefixed with the modifiers "Ctrl+", "Alt+" or "Shift+".
+func ParseKey(text string) (KeyBinding, error) {
+	var binding KeyBinding
+
+	if text == "" {
+		return binding, errors.New("the key must not be empty")
+	}
+
+	name := text
+	for {
+		separator := strings.IndexAny(name, "+-")
+		if separator <= 0 || separator == len(name)-1 {
+			break
+		}
+
+		switch strings.ToLower(name[:separator]) {
+		case "ctrl":
+			binding.Modifiers |= tcell.ModCtrl
+		case "alt":
+			binding.Modifiers |= tcell.ModAlt
+		case "shift":
+			binding.Modifiers |= tcell.ModShift
+		default:
+			return binding, fmt.Errorf("unknown modifier '%s' in key '%s'", name[:separator], text)
+		}
+		name = name[separator+1:]
+	}
+
+	if utf8.RuneCountInString(name) == 1 {
+		r, _ := utf8.DecodeRuneInString(name)
+
+		if binding.Modifiers&tcell.ModCtrl != 0 {
+			lower := unicode.ToLower(r)
+			if lower < 'a' || lower > 'z' {
+				return binding, fmt.Errorf("unsupported control key '%s'", text)
+			}
+			binding.Key = tcell.KeyCtrlA + tcell.Key(lower-'a')
+			return binding, nil
+		}
+
+		if binding.Modifiers&tcell.ModShift != 0 {
+			return binding, fmt.Errorf("use the uppercase character instead of the shift modifier in key '%s'", text)
+		}
+
+		binding.Key = tcell.KeyRune
+		binding.Rune = r
+		return binding, nil
+	}
+
+	if strings.EqualFold(name, "space") {
+		binding.Key = tcell.KeyRune
+		binding.Rune = ' '
+		return binding, nil
+	}
+
+	for key, keyName := range tcell.KeyNames {
+		if strings.EqualFold(keyName, name) && !strings.Contains(keyName, "-") {
+			binding.Key = key
+			return binding, nil
+		}
+	}
+
+	return binding, fmt.Errorf("unknown key '%s'", text)
+}
+
+// Matches determines if the key event was produced by this key combination.
+func (k KeyBinding) Matches(event *tcell.EventKey) bool {
+	if event == nil {
+		return false
+	}
+
+	modifiers := event.Modifiers() & (tcell.ModCtrl | tcell.ModAlt | tcell.ModShift)
+
+	switch {
+	case k.Key == tcell.KeyRune:
+		return event.Key() == tcell.KeyRune &&
+			event.Rune() == k.Rune &&
+			modifiers&tcell.ModAlt == k.Modifiers&tcell.ModAlt
+	case k.Key >= tcell.KeyCtrlA && k.Key <= tcell.KeyCtrlZ && k.Modifiers&tcell.ModCtrl != 0:
+		return event.Key() == k.Key
+	default:
+		return event.Key() == k.Key && modifiers == k.Modifiers
+	}
+}
+
+// ShortcutRune returns the character of the key if it is an unmodified character key, or 0 if
+// it is not. This is the format that tview uses for list item shortcuts.
+func (k KeyBinding) ShortcutRune() rune {
+	if k.Key == tcell.KeyRune && k.Modifiers == 0 {
+		return k.Rune
+	}
+
+	return 0
+}
+
+// String returns a short human-readable description of the key combination.
+func (k KeyBinding) String() string {
+	var prefix string
+	if k.Modifiers&tcell.ModCtrl != 0 && k.Key >= tcell.KeyCtrlA && k.Key <= tcell.KeyCtrlZ {
+		return fmt.Sprintf("Ctrl+%c", 'A'+rune(k.Key-tcell.KeyCtrlA))
+	}
+	if k.Modifiers&tcell.ModCtrl != 0 {
+		prefix += "Ctrl+"
+	}
+	if k.Modifiers&tcell.ModAlt != 0 {
+		prefix += "Alt+"
+	}
+	if k.Modifiers&tcell.ModShift != 0 {
+		prefix += "Shift+"
+	}
+
+	switch {
+	case k.Key == tcell.KeyRune && k.Rune == ' ':
+		return prefix + "SPACE"
+	case k.Key == tcell.KeyRune:
+		return prefix + string(k.Rune)
+	default:
+		return prefix + tcell.KeyNames[k.Key]
+	}
+}
+
+// IsValidColor determines if the text is a W3C color name, a #rrggbb hex value or "default".
+func IsValidColor(text string) bool {
+	if strings.EqualFold(text, "default") {
+		return true
+	}
+
+	return GetColor(text) != tcell.ColorDefault
+}
+
+// GetColor converts the text representation of a color to a tcell.Color.
+func GetColor(text string) tcell.Color {
+	return tcell.GetColor(strings.ToLower(text))
+}
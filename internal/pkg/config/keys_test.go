@@ -0,0 +1,79 @@
+package config
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"testing"
+)
+
+func Test_ParseKey_ParsesSupportedKeyFormats(t *testing.T) {
+	testCases := map[string]KeyBinding{
+		"q":         {Key: tcell.KeyRune, Rune: 'q'},
+		"Q":         {Key: tcell.KeyRune, Rune: 'Q'},
+		"-":         {Key: tcell.KeyRune, Rune: '-'},
+		"Space":     {Key: tcell.KeyRune, Rune: ' '},
+		"Ctrl+F":    {Key: tcell.KeyCtrlF, Modifiers: tcell.ModCtrl},
+		"ctrl-f":    {Key: tcell.KeyCtrlF, Modifiers: tcell.ModCtrl},
+		"Alt+x":     {Key: tcell.KeyRune, Rune: 'x', Modifiers: tcell.ModAlt},
+		"Alt+Left":  {Key: tcell.KeyLeft, Modifiers: tcell.ModAlt},
+		"F1":        {Key: tcell.KeyF1},
+		"pgdn":      {Key: tcell.KeyPgDn},
+		"Shift+Tab": {Key: tcell.KeyTab, Modifiers: tcell.ModShift},
+	}
+
+	for text, expected := range testCases {
+		result, err := ParseKey(text)
+		if err != nil {
+			t.Errorf("Expected key '%s' to be valid, got '%v' instead", text, err)
+		} else if result != expected {
+			t.Errorf("Expected key '%s' to be parsed as '%+v', got '%+v' instead", text, expected, result)
+		}
+	}
+}
+
+func Test_ParseKey_ReturnsErrorForInvalidKeys(t *testing.T) {
+	for _, text := range []string{"", "Hyper+x", "Ctrl+1", "Shift+a", "NotAKey"} {
+		if _, err := ParseKey(text); err == nil {
+			t.Errorf("Expected key '%s' to be invalid", text)
+		}
+	}
+}
+
+func Test_KeyBinding_Matches_MatchesEquivalentKeyEvents(t *testing.T) {
+	testCases := map[string]*tcell.EventKey{
+		"q":        tcell.NewEventKey(tcell.KeyRune, 'q', tcell.ModNone),
+		"Ctrl+F":   tcell.NewEventKey(tcell.KeyCtrlF, 0, tcell.ModCtrl),
+		"Alt+x":    tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModAlt),
+		"Alt+Left": tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModAlt),
+	}
+
+	for text, event := range testCases {
+		binding, err := ParseKey(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !binding.Matches(event) {
+			t.Errorf("Expected key '%s' to match the event '%v'", text, event.Name())
+		}
+	}
+}
+
+func Test_KeyBinding_Matches_DoesNotMatchDifferentModifiers(t *testing.T) {
+	binding, err := ParseKey("Alt+Left")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if binding.Matches(tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModNone)) {
+		t.Error("Expected 'Alt+Left' not to match an unmodified left arrow key")
+	}
+
+	binding, err = ParseKey("x")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if binding.Matches(tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModAlt)) {
+		t.Error("Expected 'x' not to match 'Alt+x'")
+	}
+}
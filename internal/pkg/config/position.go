@@ -0,0 +1,190 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// keyPosition records where a setting appears in the configuration data.
+type keyPosition struct {
+	path   []string
+	offset int64
+}
+
+// keyIndex is the list of settings in the configuration data in the order they appear.
+type keyIndex struct {
+	keys []keyPosition
+}
+
+// offsetOf returns the offset of the setting with the specified path, or -1 if the setting
+// does not appear in the data.
+func (k *keyIndex) offsetOf(path ...string) int64 {
+	for _, key := range k.keys {
+		if reflect.DeepEqual(key.path, path) {
+			return key.offset
+		}
+	}
+
+	return -1
+}
+
+// indexKeys walks the JSON data and records the path and offset of every object key.
+func indexKeys(data []byte) (*keyIndex, error) {
+	index := &keyIndex{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	var walk func(path []string) error
+	walk = func(path []string) error {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		switch token {
+		case json.Delim('{'):
+			for decoder.More() {
+				offset := decoder.InputOffset()
+				keyToken, err := decoder.Token()
+				if err != nil {
+					return err
+				}
+
+				key := keyToken.(string)
+				keyPath := append(append([]string{}, path...), key)
+				index.keys = append(index.keys, keyPosition{path: keyPath, offset: offset})
+
+				if err = walk(keyPath); err != nil {
+					return err
+				}
+			}
+			_, err = decoder.Token()
+			return err
+		case json.Delim('['):
+			for decoder.More() {
+				if err = walk(path); err != nil {
+					return err
+				}
+			}
+			_, err = decoder.Token()
+			return err
+		}
+
+		return nil
+	}
+
+	if err := walk(nil); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("the configuration is empty or incomplete")
+		}
+		return nil, err
+	}
+
+	return index, nil
+}
+
+// lineAt returns the one-based line number of the byte at the specified offset. Offsets that
+// precede any whitespace before a key are advanced to the key itself.
+func lineAt(data []byte, offset int64) int {
+	if offset < 0 {
+		return 0
+	}
+
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+
+	for offset < int64(len(data)) && strings.ContainsRune(" \t\r\n,:", rune(data[offset])) {
+		offset++
+	}
+
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+// newErrorAtOffset converts JSON decoding errors to an Error with the line number of the
+// problem in the configuration data.
+func newErrorAtOffset(fileName string, data []byte, err error) error {
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+	)
+
+	switch {
+	case errors.As(err, &syntaxErr):
+		return &Error{File: fileName, Line: lineAt(data, syntaxErr.Offset-1), Err: err}
+	case errors.As(err, &typeErr):
+		return &Error{
+			File: fileName,
+			Line: lineAt(data, typeErr.Offset-1),
+			Err:  fmt.Errorf("invalid value for '%s', expected a %v", typeErr.Field, typeErr.Type),
+		}
+	default:
+		return &Error{File: fileName, Err: err}
+	}
+}
+
+// isKnownKey determines if the path refers to a setting of the Config struct.
+func isKnownKey(path []string) bool {
+	t := reflect.TypeOf(Config{})
+
+	for _, name := range path {
+		switch t.Kind() {
+		case reflect.Map:
+			t = t.Elem()
+		case reflect.Struct:
+			field, found := fieldByJSONName(t, name)
+			if !found {
+				return false
+			}
+			t = field.Type
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// fieldByJSONName finds the exported struct field with the specified JSON name.
+func fieldByJSONName(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		tag := strings.Split(field.Tag.Get("json"), ",")[0]
+		if tag == name || (tag == "" && strings.EqualFold(field.Name, name)) {
+			return field, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+// sortedKeys returns the keys of the map in alphabetical order.
+func sortedKeys(m map[string]string) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// contains determines if the list contains the value.
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,26 @@
+//go:build darwin || freebsd || netbsd
+// +build darwin freebsd netbsd
+
+package dirctrl
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// getBirthTime returns the time that the file at the path was created. It returns false if the
+// birth time is unavailable.
+func getBirthTime(path string) (time.Time, bool) {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	return time.Unix(int64(stat.Birthtimespec.Sec), int64(stat.Birthtimespec.Nsec)), true
+}
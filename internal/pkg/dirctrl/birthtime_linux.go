@@ -0,0 +1,20 @@
+package dirctrl
+
+import (
+	"golang.org/x/sys/unix"
+	"time"
+)
+
+// getBirthTime returns the time that the file at the path was created. It returns false if the
+// kernel or filesystem does not record birth times.
+func getBirthTime(path string) (time.Time, bool) {
+	var stat unix.Statx_t
+	if err := unix.Statx(unix.AT_FDCWD, path, unix.AT_SYMLINK_NOFOLLOW, unix.STATX_BTIME, &stat); err != nil {
+		return time.Time{}, false
+	}
+	if stat.Mask&unix.STATX_BTIME == 0 {
+		return time.Time{}, false
+	}
+
+	return time.Unix(stat.Btime.Sec, int64(stat.Btime.Nsec)), true
+}
@@ -0,0 +1,12 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !windows
+// +build !linux,!darwin,!freebsd,!netbsd,!windows
+
+package dirctrl
+
+import "time"
+
+// getBirthTime returns the time that the file at the path was created. Birth times are not
+// read on this platform, so it always returns false.
+func getBirthTime(string) (time.Time, bool) {
+	return time.Time{}, false
+}
@@ -0,0 +1,23 @@
+package dirctrl
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// getBirthTime returns the time that the file at the path was created. It returns false if the
+// creation time is unavailable.
+func getBirthTime(path string) (time.Time, bool) {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	data, ok := info.Sys().(*syscall.Win32FileAttributeData)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	return time.Unix(0, data.CreationTime.Nanoseconds()), true
+}
@@ -3,6 +3,10 @@ package dirctrl
 import (
 	"errors"
 	"fmt"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/config"
+	"github.com/karrick/godirwalk"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
 	"io/fs"
 	"io/ioutil"
 	"log"
@@ -17,11 +21,21 @@ type DirectoryCommands interface {
 	ReadDirectory(dirname string) ([]fs.FileInfo, error)
 	GetAbsolutePath(path string) (string, error)
 	ScanDirectory(path string, callback func(dirName string)) error
+	SetSortOrder(mode string, reverse bool)
+	WalkDirectory(root string, maxDepth int, callback func(relativePath string) error) error
 }
 
-// DefaultDirectoryCommands is a placeholder struct for implemented methods
-// of the DirectoryCommands interface.
-type DefaultDirectoryCommands struct{}
+// DefaultDirectoryCommands contains the implemented methods of the DirectoryCommands
+// interface. SortMode is one of the config.SortModes and defaults to sorting by name, and
+// SortReverse reverses the comparisons of the sort mode. Collate compares names
+// with the Unicode collation rules of the user's locale. Score returns the frecency of the
+// directory at a path, which is used by the frecency sort mode.
+type DefaultDirectoryCommands struct {
+	SortMode    string
+	SortReverse bool
+	Collate     bool
+	Score       func(path string) float64
+}
 
 // ReadDirectory returns a list of fs.FileInfo objects from the specified directory.
 func (d *DefaultDirectoryCommands) ReadDirectory(dirname string) ([]fs.FileInfo, error) {
@@ -32,14 +46,64 @@ func (d *DefaultDirectoryCommands) ReadDirectory(dirname string) ([]fs.FileInfo,
 			"unable to read directory: %w",
 			err)
 	}
-	sort.Slice(items, d.getFileInfoSliceSortHandler(items))
+	sort.Slice(items, d.getSortHandler(dirname, items))
 
 	return items, nil
 }
 
+// SetSortOrder sets the order in which directory items are read.
+func (d *DefaultDirectoryCommands) SetSortOrder(mode string, reverse bool) {
+	d.SortMode = mode
+	d.SortReverse = reverse
+}
+
+// getSortHandler sorts a list of files in the directory by the sort mode. Files that are
+// equal in the sort mode are sorted by name. The newest, largest and most frecent files are
+// sorted first. Directories are sized by the size that the filesystem reports for them, so
+// that sorting does not read every subdirectory. SortReverse reverses the comparisons, so the
+// reversed name order still lists dotfiles and underscore prefixed files first.
+func (d *DefaultDirectoryCommands) getSortHandler(dirname string, items []fs.FileInfo) func(i, j int) bool {
+	byName := d.getFileInfoSliceSortHandler(items)
+
+	switch d.SortMode {
+	case config.SortModeModTime:
+		return func(i, j int) bool {
+			if timeI, timeJ := items[i].ModTime(), items[j].ModTime(); !timeI.Equal(timeJ) {
+				return timeI.After(timeJ) != d.SortReverse
+			}
+			return byName(i, j)
+		}
+	case config.SortModeSize:
+		return func(i, j int) bool {
+			if sizeI, sizeJ := items[i].Size(), items[j].Size(); sizeI != sizeJ {
+				return (sizeI > sizeJ) != d.SortReverse
+			}
+			return byName(i, j)
+		}
+	case config.SortModeFrecency:
+		scores := make(map[string]float64)
+		if d.Score != nil {
+			for _, item := range items {
+				scores[item.Name()] = d.Score(filepath.Join(dirname, item.Name()))
+			}
+		}
+		return func(i, j int) bool {
+			if scoreI, scoreJ := scores[items[i].Name()], scores[items[j].Name()]; scoreI != scoreJ {
+				return (scoreI > scoreJ) != d.SortReverse
+			}
+			return byName(i, j)
+		}
+	}
+
+	return byName
+}
+
 // getFileInfoSliceSortHandler sorts a list of files case-insensitively and with underscore
-// prefixed files ordered above alphanumeric ones.
-func (*DefaultDirectoryCommands) getFileInfoSliceSortHandler(items []fs.FileInfo) func (i, j int) bool {
+// prefixed files ordered above alphanumeric ones. SortReverse reverses the order of the names
+// within these groups.
+func (d *DefaultDirectoryCommands) getFileInfoSliceSortHandler(items []fs.FileInfo) func (i, j int) bool {
+	compareNames := d.getNameComparer()
+
 	return func(i, j int) bool {
 		compareI := items[i].Name()
 		compareJ := items[j].Name()
@@ -55,21 +119,119 @@ func (*DefaultDirectoryCommands) getFileInfoSliceSortHandler(items []fs.FileInfo
 		runeI := rune(compareI[0])
 		runeJ := rune(compareJ[0])
 
-		// Sort files beginning with an underscore after dotfiles but before everything else
-		if runeI == '_' && runeJ == '.' {
-			return false
-		} else if runeI == '_' && runeJ != '_' {
+		// Sort dotfiles before everything else
+		if runeI == '.' && runeJ != '.' {
 			return true
-		} else if runeJ == '_' && runeI == '.' {
+		} else if runeJ == '.' && runeI != '.' {
+			return false
+		}
+
+		// Sort files beginning with an underscore after dotfiles but before everything else
+		if runeI == '_' && runeJ != '_' {
 			return true
 		} else if runeJ == '_' && runeI != '_' {
 			return false
 		}
 
-		return strings.ToLower(compareI) < strings.ToLower(compareJ)
+		if d.SortReverse {
+			return compareNames(compareI, compareJ) > 0
+		}
+
+		return compareNames(compareI, compareJ) < 0
 	}
 }
 
+// getNameComparer returns a function that compares file names case-insensitively. The
+// natural sort mode compares runs of digits by their numeric value.
+func (d *DefaultDirectoryCommands) getNameComparer() func(a, b string) int {
+	natural := d.SortMode == config.SortModeNatural
+
+	if d.Collate {
+		options := []collate.Option{collate.IgnoreCase}
+		if natural {
+			options = append(options, collate.Numeric)
+		}
+		// Note: A Collator is not safe for concurrent use, so each sort creates its own.
+		collator := collate.New(getLocaleLanguage(), options...)
+		return collator.CompareString
+	}
+
+	if natural {
+		return compareNatural
+	}
+
+	return func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	}
+}
+
+// getLocaleLanguage returns the language of the user's locale for collation, or the root
+// language if the locale is not set or unknown.
+func getLocaleLanguage() language.Tag {
+	for _, variable := range []string{"LC_ALL", "LC_COLLATE", "LANG"} {
+		locale := os.Getenv(variable)
+		if locale == "" {
+			continue
+		}
+
+		// Note: Locales such as en_US.UTF-8@euro are converted to language tags such as en-US.
+		locale = strings.SplitN(strings.SplitN(locale, ".", 2)[0], "@", 2)[0]
+		if tag, err := language.Parse(strings.Replace(locale, "_", "-", -1)); err == nil {
+			return tag
+		}
+		break
+	}
+
+	return language.Und
+}
+
+// compareNatural compares strings case-insensitively, with runs of digits compared by their
+// numeric value so that "v2" is ordered before "v10".
+func compareNatural(a, b string) int {
+	a, b = strings.ToLower(a), strings.ToLower(b)
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if isDigit(a[i]) && isDigit(b[j]) {
+			startI, startJ := i, j
+			for i < len(a) && isDigit(a[i]) {
+				i++
+			}
+			for j < len(b) && isDigit(b[j]) {
+				j++
+			}
+
+			numberI := strings.TrimLeft(a[startI:i], "0")
+			numberJ := strings.TrimLeft(b[startJ:j], "0")
+			if len(numberI) != len(numberJ) {
+				return len(numberI) - len(numberJ)
+			}
+			if result := strings.Compare(numberI, numberJ); result != 0 {
+				return result
+			}
+			continue
+		}
+
+		if a[i] != b[j] {
+			return int(a[i]) - int(b[j])
+		}
+		i++
+		j++
+	}
+
+	if result := (len(a) - i) - (len(b) - j); result != 0 {
+		return result
+	}
+
+	// Note: Numbers that only differ in their leading zeros are ordered by their text.
+	return strings.Compare(a, b)
+}
+
+// isDigit determines if the byte is an ASCII digit.
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
 // GetAbsolutePath gets the full path of the specified directory.
 func (*DefaultDirectoryCommands) GetAbsolutePath(path string) (string, error) {
 	return filepath.Abs(path)
@@ -118,3 +280,71 @@ func (d *DefaultDirectoryCommands) ScanDirectory(
 
 	return nil
 }
+
+// WalkDirectory walks the directories below the root, at most maxDepth levels deep, and calls
+// the callback with the path of each directory relative to the root. Symlinks to directories
+// are reported but not followed, and directories on a different filesystem than the root are
+// skipped. Unreadable directories are skipped as well. The callback returns filepath.SkipDir
+// to skip the descendants of a directory, or any other error to stop the walk with that error.
+func (d *DefaultDirectoryCommands) WalkDirectory(
+	root string,
+	maxDepth int,
+	callback func(relativePath string) error,
+) error {
+	if callback == nil {
+		return errors.New("callback function must not be nil")
+	}
+
+	// Note: godirwalk does not descend into a root that is a symlink, so the symlink is
+	//  resolved first.
+	root, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return fmt.Errorf("unable to walk directory, the path is invalid: %w", err)
+	}
+	rootDevice, hasDevice := getDevice(root)
+
+	var callbackErr error
+	err = godirwalk.Walk(root, &godirwalk.Options{
+		Callback: func(osPathname string, entry *godirwalk.Dirent) error {
+			if osPathname == root {
+				return nil
+			}
+
+			if isDir, err := entry.IsDirOrSymlinkToDir(); err != nil || !isDir {
+				return godirwalk.SkipThis
+			}
+
+			if hasDevice && entry.IsDir() {
+				if device, ok := getDevice(osPathname); ok && device != rootDevice {
+					return godirwalk.SkipThis
+				}
+			}
+
+			relativePath, err := filepath.Rel(root, osPathname)
+			if err != nil {
+				return godirwalk.SkipThis
+			}
+
+			if err = callback(relativePath); err == filepath.SkipDir {
+				return godirwalk.SkipThis
+			} else if err != nil {
+				callbackErr = err
+				return err
+			}
+
+			if strings.Count(relativePath, OsPathSeparator)+1 >= maxDepth {
+				return godirwalk.SkipThis
+			}
+
+			return nil
+		},
+		ErrorCallback: func(_ string, err error) godirwalk.ErrorAction {
+			if err == callbackErr {
+				return godirwalk.Halt
+			}
+			return godirwalk.SkipNode
+		},
+	})
+
+	return err
+}
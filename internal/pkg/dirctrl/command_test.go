@@ -3,6 +3,7 @@ package dirctrl
 import (
 	"bytes"
 	"fmt"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/config"
 	"github.com/goldenpathtechnologies/ci/testdata/mock"
 	"github.com/google/uuid"
 	"io/fs"
@@ -421,3 +422,228 @@ func Test_DefaultDirectoryCommands_ReadDirectory_ReturnsSortedListOfFiles(t *tes
 		}
 	}
 }
+
+func getFileNamesForTest(files []fs.FileInfo) []string {
+	var names []string
+	for _, file := range files {
+		names = append(names, file.Name())
+	}
+
+	return names
+}
+
+func Test_DefaultDirectoryCommands_getSortHandler_SortsBySortMode(t *testing.T) {
+	now := time.Now()
+	newFiles := func() []fs.FileInfo {
+		return []fs.FileInfo{
+			mock.File{FileName: "v10", FileSize: 30, FileModTime: now.Add(-time.Hour)},
+			mock.File{FileName: "v2", FileSize: 10, FileModTime: now},
+			mock.File{FileName: "v1", FileSize: 20, FileModTime: now.Add(-time.Minute)},
+		}
+	}
+	scores := map[string]float64{
+		filepath.Join("dir", "v1"):  2,
+		filepath.Join("dir", "v10"): 5,
+	}
+	testCases := map[string][]string{
+		"":                      {"v1", "v10", "v2"},
+		config.SortModeName:     {"v1", "v10", "v2"},
+		config.SortModeNatural:  {"v1", "v2", "v10"},
+		config.SortModeModTime:  {"v2", "v1", "v10"},
+		config.SortModeSize:     {"v10", "v1", "v2"},
+		config.SortModeFrecency: {"v10", "v1", "v2"},
+	}
+
+	for mode, expected := range testCases {
+		commands := &DefaultDirectoryCommands{
+			SortMode: mode,
+			Score: func(path string) float64 {
+				return scores[path]
+			},
+		}
+		files := newFiles()
+
+		sort.Slice(files, commands.getSortHandler("dir", files))
+
+		if result := getFileNamesForTest(files); strings.Join(result, " ") != strings.Join(expected, " ") {
+			t.Errorf("Expected sort mode '%s' to order the files as %v, got %v instead", mode, expected, result)
+		}
+	}
+}
+
+func Test_DefaultDirectoryCommands_getSortHandler_SortsDirectoriesBySizeWithoutReadingThem(t *testing.T) {
+	files := []fs.FileInfo{
+		mock.File{FileName: "few", FileSize: 64, FileMode: fs.ModeDir},
+		mock.File{FileName: "many", FileSize: 4096, FileMode: fs.ModeDir},
+		mock.File{FileName: "none", FileSize: 0, FileMode: fs.ModeDir},
+	}
+	commands := &DefaultDirectoryCommands{SortMode: config.SortModeSize}
+
+	// Note: The directories do not exist, so they would all be empty if they were read.
+	sort.Slice(files, commands.getSortHandler(filepath.Join(t.TempDir(), "missing"), files))
+
+	if result := getFileNamesForTest(files); strings.Join(result, " ") != "many few none" {
+		t.Errorf("Expected the directories to be ordered by their size, got %v instead", result)
+	}
+}
+
+func Test_DefaultDirectoryCommands_getSortHandler_ReverseKeepsDotfilesAndUnderscoresFirst(t *testing.T) {
+	now := time.Now()
+	newFiles := func() []fs.FileInfo {
+		return []fs.FileInfo{
+			mock.File{FileName: "beta", FileSize: 10, FileModTime: now.Add(-time.Hour)},
+			mock.File{FileName: "_private", FileSize: 30, FileModTime: now},
+			mock.File{FileName: ".config", FileSize: 20, FileModTime: now},
+			mock.File{FileName: "alpha", FileSize: 20, FileModTime: now.Add(-time.Minute)},
+			mock.File{FileName: ".cache", FileSize: 10, FileModTime: now},
+		}
+	}
+	testCases := map[string][]string{
+		config.SortModeName:    {".config", ".cache", "_private", "beta", "alpha"},
+		config.SortModeModTime: {"beta", "alpha", ".config", ".cache", "_private"},
+		config.SortModeSize:    {".cache", "beta", ".config", "alpha", "_private"},
+	}
+
+	for mode, expected := range testCases {
+		commands := &DefaultDirectoryCommands{SortMode: mode, SortReverse: true}
+		files := newFiles()
+
+		sort.Slice(files, commands.getSortHandler("dir", files))
+
+		if result := getFileNamesForTest(files); strings.Join(result, " ") != strings.Join(expected, " ") {
+			t.Errorf("Expected reversed sort mode '%s' to order the files as %v, got %v instead", mode, expected, result)
+		}
+	}
+}
+
+func Test_DefaultDirectoryCommands_getSortHandler_ComparesNamesByCollationWhenConfigured(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_COLLATE", "")
+	t.Setenv("LANG", "en_US.UTF-8")
+	newFiles := func() []fs.FileInfo {
+		return []fs.FileInfo{
+			mock.File{FileName: "zebra"},
+			mock.File{FileName: "Étude"},
+			mock.File{FileName: "apple"},
+		}
+	}
+
+	files := newFiles()
+	commands := &DefaultDirectoryCommands{}
+	sort.Slice(files, commands.getSortHandler("dir", files))
+
+	if result := getFileNamesForTest(files); strings.Join(result, " ") != "apple zebra Étude" {
+		t.Errorf("Expected accented names to be ordered by byte value, got %v instead", result)
+	}
+
+	files = newFiles()
+	commands.Collate = true
+	sort.Slice(files, commands.getSortHandler("dir", files))
+
+	if result := getFileNamesForTest(files); strings.Join(result, " ") != "apple Étude zebra" {
+		t.Errorf("Expected accented names to be collated, got %v instead", result)
+	}
+}
+
+func Test_compareNatural_ComparesRunsOfDigitsNumerically(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		expected int
+	}{
+		{"v2", "v10", -1},
+		{"svc-foo-v10", "svc-foo-v9", 1},
+		{"File007", "file7", -1},
+		{"release-1.2.3", "release-1.10.0", -1},
+		{"abc", "ABC", 0},
+	}
+
+	for _, testCase := range testCases {
+		result := compareNatural(testCase.a, testCase.b)
+		if (result < 0 && testCase.expected >= 0) || (result > 0 && testCase.expected <= 0) || (result == 0 && testCase.expected != 0) {
+			t.Errorf("Expected compareNatural('%s', '%s') to have the sign of %d, got %d instead", testCase.a, testCase.b, testCase.expected, result)
+		}
+	}
+}
+
+func getWalkedPathsForTest(t *testing.T, commands *DefaultDirectoryCommands, root string, maxDepth int, callback func(relativePath string) error) []string {
+	var paths []string
+	if err := commands.WalkDirectory(root, maxDepth, func(relativePath string) error {
+		paths = append(paths, filepath.ToSlash(relativePath))
+		if callback != nil {
+			return callback(relativePath)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	return paths
+}
+
+func Test_DefaultDirectoryCommands_WalkDirectory_ReportsDirectoriesUpToMaxDepth(t *testing.T) {
+	tempDir := t.TempDir()
+	createDirectory := getCreateDirectoryForTestHandler(tempDir, t)
+	createDirectory(filepath.Join("services", "api", "internal", "db"))
+	createDirectory("web")
+	getCreateFileForTestHandler(tempDir, t)("README.md")
+
+	result := getWalkedPathsForTest(t, &DefaultDirectoryCommands{}, tempDir, 3, nil)
+
+	expected := []string{"services", "services/api", "services/api/internal", "web"}
+	if fmt.Sprint(result) != fmt.Sprint(expected) {
+		t.Errorf("Expected the paths %v, got %v instead", expected, result)
+	}
+}
+
+func Test_DefaultDirectoryCommands_WalkDirectory_ReportsSymlinksWithoutFollowingThem(t *testing.T) {
+	tempDir := t.TempDir()
+	targetDir := t.TempDir()
+	getCreateDirectoryForTestHandler(targetDir, t)("nested")
+	getCreateSymlinkForTestHandler(tempDir, t)("link", targetDir)
+
+	result := getWalkedPathsForTest(t, &DefaultDirectoryCommands{}, tempDir, 5, nil)
+
+	if fmt.Sprint(result) != fmt.Sprint([]string{"link"}) {
+		t.Errorf("Expected only the symlink to be reported, got %v instead", result)
+	}
+}
+
+func Test_DefaultDirectoryCommands_WalkDirectory_SkipsDescendantsWhenCallbackReturnsSkipDir(t *testing.T) {
+	tempDir := t.TempDir()
+	createDirectory := getCreateDirectoryForTestHandler(tempDir, t)
+	createDirectory(filepath.Join("build", "output"))
+	createDirectory(filepath.Join("src", "app"))
+
+	result := getWalkedPathsForTest(t, &DefaultDirectoryCommands{}, tempDir, 5, func(relativePath string) error {
+		if relativePath == "build" {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+
+	expected := []string{"build", "src", "src/app"}
+	if fmt.Sprint(result) != fmt.Sprint(expected) {
+		t.Errorf("Expected the paths %v, got %v instead", expected, result)
+	}
+}
+
+func Test_DefaultDirectoryCommands_WalkDirectory_StopsWithErrorOfCallback(t *testing.T) {
+	tempDir := t.TempDir()
+	createDirectory := getCreateDirectoryForTestHandler(tempDir, t)
+	createDirectory("a")
+	createDirectory("b")
+
+	stopErr := fmt.Errorf("stop")
+	var paths []string
+	err := (&DefaultDirectoryCommands{}).WalkDirectory(tempDir, 5, func(relativePath string) error {
+		paths = append(paths, relativePath)
+		return stopErr
+	})
+
+	if err != stopErr {
+		t.Errorf("Expected the error of the callback, got '%v' instead", err)
+	}
+	if len(paths) != 1 {
+		t.Errorf("Expected the walk to stop after the first directory, got %v instead", paths)
+	}
+}
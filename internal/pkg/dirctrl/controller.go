@@ -1,37 +1,92 @@
 package dirctrl
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
 )
 
 // DirectoryController specifies the abstracted filesystem functions that ci uses.
 type DirectoryController interface {
-	GetInitialDirectory() (string, error)
+	GetInitialDirectory(path string) (string, error)
 	DirectoryIsAccessible(dir string) bool
 	GetDirectoryInfo(dir string) (string, error)
 	GetAbsolutePath(dir string) (string, error)
 	ScanDirectory(path string, callback func(dirName string)) error
+	ScanFiles(path string, callback func(fileName string)) error
+	FindDirectory(start string, fragments []string) (string, error)
+	SetShowHidden(showHidden bool)
+	IsIgnored(path string) bool
+	SetShowIgnored(showIgnored bool)
+	SetSortOrder(mode string, reverse bool)
+	SearchDirectory(path string, options SearchOptions, callback func(relativePath string) bool) error
+	GetDirectorySize(path string, stop <-chan struct{}) (DirectorySize, error)
+}
+
+// errSearchStopped stops a search when its callback asks for no more directories.
+var errSearchStopped = errors.New("the search was stopped")
+
+// SearchOptions are the settings of a search of the directories below a path. A search gets
+// its own copy of the settings, so that the settings of the DirectoryController can change
+// while the search runs in the background.
+type SearchOptions struct {
+	MaxDepth    int
+	ShowHidden  bool
+	ShowIgnored bool
 }
 
 // DefaultDirectoryController contains a collection of methods that execute various
-// commands on the filesystem.
+// commands on the filesystem. ShowHidden determines whether hidden files are included in
+// the directory info. When Ignore is set, directories that it ignores are skipped unless
+// ShowIgnored is true. Columns are the config.InfoColumns of the directory info, and
+// TimeFormat is either config.TimeFormatRelative or a layout of the time package.
 type DefaultDirectoryController struct {
-	Writer   InfoWriter
-	Commands DirectoryCommands
+	Writer      InfoWriter
+	Commands    DirectoryCommands
+	ShowHidden  bool
+	Ignore      *IgnoreRules
+	ShowIgnored bool
+	Columns     []string
+	TimeFormat  string
 }
 
 // NewDefaultDirectoryController creates a new instance of DefaultDirectoryController with
 // initialized methods.
 func NewDefaultDirectoryController() *DefaultDirectoryController {
 	return &DefaultDirectoryController{
-		Writer:   NewDefaultInfoWriter(),
-		Commands: &DefaultDirectoryCommands{},
+		Writer:     NewDefaultInfoWriter(),
+		Commands:   &DefaultDirectoryCommands{},
+		ShowHidden: true,
 	}
 }
 
-// GetInitialDirectory returns the path that ci was run from.
-func (d *DefaultDirectoryController) GetInitialDirectory() (string, error) {
-	return d.Commands.GetAbsolutePath(".")
+// GetInitialDirectory returns the absolute path of the directory that ci starts in. The
+// path may begin with a tilde or contain environment variables. An empty path resolves to
+// the directory that ci was run from.
+func (d *DefaultDirectoryController) GetInitialDirectory(path string) (string, error) {
+	if path == "" {
+		path = "."
+	}
+
+	directory, err := d.Commands.GetAbsolutePath(ExpandPath(path))
+	if err != nil {
+		return "", &DirectoryError{
+			Err:       err,
+			ErrorCode: DirUnexpectedError,
+		}
+	}
+
+	if !d.DirectoryIsAccessible(directory) {
+		return "", &DirectoryError{
+			Err:       fmt.Errorf("unable to open '%s', the directory does not exist or is inaccessible", path),
+			ErrorCode: DirInvalidPathError,
+		}
+	}
+
+	return directory, nil
 }
 
 // DirectoryIsAccessible determines if a directory is accessible to the current user.
@@ -41,7 +96,8 @@ func (d *DefaultDirectoryController) DirectoryIsAccessible(directory string) boo
 	return err == nil
 }
 
-// GetDirectoryInfo returns a formatted list of files in the specified directory.
+// GetDirectoryInfo returns a formatted list of files in the specified directory, with one
+// column for each of the Columns.
 func (d *DefaultDirectoryController) GetDirectoryInfo(directory string) (string, error) {
 	files, err := d.Commands.ReadDirectory(directory)
 	if err != nil {
@@ -53,26 +109,38 @@ func (d *DefaultDirectoryController) GetDirectoryInfo(directory string) (string,
 
 	// TODO: Return with a message if there are no files in the directory.
 
-	_, err = fmt.Fprintf(d.Writer, "%v\t%v\t%v\t%v\n", "Mode", "Name", "ModTime", "Bytes")
-	if err != nil {
-		return "", &DirectoryError{
-			Err:       err,
-			ErrorCode: DirUnexpectedError,
-		}
+	columns := d.Columns
+	if len(columns) == 0 {
+		columns = defaultInfoColumns
 	}
 
-	_, err = fmt.Fprintf(d.Writer, "%v\t%v\t%v\t%v\n", "----", "----", "-------", "-----")
-	if err != nil {
-		return "", &DirectoryError{
-			Err:       err,
-			ErrorCode: DirUnexpectedError,
+	headers := make([]string, len(columns))
+	dividers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = infoColumnHeaders[column]
+		dividers[i] = strings.Repeat("-", len(headers[i]))
+	}
+
+	for _, row := range [][]string{headers, dividers} {
+		if _, err = fmt.Fprintf(d.Writer, "%v\n", strings.Join(row, "\t")); err != nil {
+			return "", &DirectoryError{
+				Err:       err,
+				ErrorCode: DirUnexpectedError,
+			}
 		}
 	}
 
 	for _, f := range files {
-		dateFormat := "2006-01-02 3:04 PM"
-		modTime := f.ModTime().Format(dateFormat)
-		_, err = fmt.Fprintf(d.Writer, "%v\t%v\t%v\t%v\n", f.Mode(), f.Name(), modTime, f.Size())
+		if !d.ShowHidden && IsHidden(f.Name()) {
+			continue
+		}
+
+		values := make([]string, len(columns))
+		for i, column := range columns {
+			values[i] = d.getInfoColumnValue(column, directory, f)
+		}
+
+		_, err = fmt.Fprintf(d.Writer, "%v\n", strings.Join(values, "\t"))
 		if err != nil {
 			return "", &DirectoryError{
 				Err:       err,
@@ -93,13 +161,117 @@ func (d *DefaultDirectoryController) GetDirectoryInfo(directory string) (string,
 	return output, nil
 }
 
+// SetShowHidden sets whether hidden files are included in the directory info.
+func (d *DefaultDirectoryController) SetShowHidden(showHidden bool) {
+	d.ShowHidden = showHidden
+}
+
+// SetSortOrder sets the sort mode, one of the config.SortModes, and direction in which the
+// items of directories are read.
+func (d *DefaultDirectoryController) SetSortOrder(mode string, reverse bool) {
+	d.Commands.SetSortOrder(mode, reverse)
+}
+
 // GetAbsolutePath gets the full path of the specified directory.
 func (d *DefaultDirectoryController) GetAbsolutePath(directory string) (string, error) {
 	return d.Commands.GetAbsolutePath(directory)
 }
 
 // ScanDirectory iterates over each file in the path and executes a callback that is
-// provided the name of that file.
+// provided the name of that file. Ignored directories are skipped unless they are shown.
 func (d *DefaultDirectoryController) ScanDirectory(path string, callback func(dirName string)) error {
-	return d.Commands.ScanDirectory(path, callback)
+	if d.Ignore == nil || d.ShowIgnored || callback == nil {
+		return d.Commands.ScanDirectory(path, callback)
+	}
+
+	return d.Commands.ScanDirectory(path, func(dirName string) {
+		if !d.Ignore.IsIgnored(filepath.Join(path, dirName)) {
+			callback(dirName)
+		}
+	})
+}
+
+// ScanFiles iterates over each file in the path that is not a directory, or a symlink to one,
+// and executes a callback that is provided the name of that file. Ignored files are skipped
+// unless they are shown.
+func (d *DefaultDirectoryController) ScanFiles(path string, callback func(fileName string)) error {
+	if callback == nil {
+		return errors.New("callback function must not be nil")
+	}
+
+	files, err := d.Commands.ReadDirectory(path)
+	if err != nil {
+		return &DirectoryError{
+			Err:       err,
+			ErrorCode: DirUnprivilegedError,
+		}
+	}
+
+	for _, file := range files {
+		filePath := filepath.Join(path, file.Name())
+		if file.IsDir() {
+			continue
+		}
+		if file.Mode()&fs.ModeSymlink != 0 {
+			if info, err := os.Stat(filePath); err == nil && info.IsDir() {
+				continue
+			}
+		}
+		if d.Ignore != nil && !d.ShowIgnored && d.Ignore.IsIgnored(filePath) {
+			continue
+		}
+
+		callback(file.Name())
+	}
+
+	return nil
+}
+
+// IsIgnored determines if the directory at the path matches the ignore rules.
+func (d *DefaultDirectoryController) IsIgnored(path string) bool {
+	return d.Ignore != nil && d.Ignore.IsIgnored(path)
+}
+
+// SetShowIgnored sets whether ScanDirectory includes ignored directories.
+func (d *DefaultDirectoryController) SetShowIgnored(showIgnored bool) {
+	d.ShowIgnored = showIgnored
+}
+
+// SearchDirectory walks the directories below the path, at most MaxDepth levels deep, and
+// calls the callback with the path of each directory relative to the path, until the callback
+// returns false. Git directories are skipped along with their descendants, as are hidden and
+// ignored directories unless the options show them.
+func (d *DefaultDirectoryController) SearchDirectory(
+	path string,
+	options SearchOptions,
+	callback func(relativePath string) bool,
+) error {
+	ignore := d.Ignore
+	if options.ShowIgnored {
+		ignore = nil
+	}
+
+	err := d.Commands.WalkDirectory(path, options.MaxDepth, func(relativePath string) error {
+		name := filepath.Base(relativePath)
+		if name == gitDirName ||
+			(!options.ShowHidden && IsHidden(name)) ||
+			(ignore != nil && ignore.IsIgnored(filepath.Join(path, relativePath))) {
+			return filepath.SkipDir
+		}
+
+		if !callback(relativePath) {
+			return errSearchStopped
+		}
+
+		return nil
+	})
+
+	if err != nil && err != errSearchStopped {
+		return &DirectoryError{
+			Err:       err,
+			ErrorCode: DirUnexpectedError,
+		}
+	}
+
+	return nil
 }
@@ -2,8 +2,11 @@ package dirctrl
 
 import (
 	"errors"
+	"fmt"
 	"github.com/goldenpathtechnologies/ci/testdata/mock"
 	"io/fs"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -138,3 +141,199 @@ func Test_DefaultDirectoryController_GetDirectoryInfo_ReturnsListOfFilesInDirect
 		t.Errorf("Expected to see specified files in output, got the following output instead:\n%s\n", output)
 	}
 }
+
+func Test_DefaultDirectoryController_GetInitialDirectory_ReturnsAbsolutePathOfSuppliedDirectory(t *testing.T) {
+	seedDirectories := mock.GetHierarchicalSeedDirectories()
+	mockFileSystem := mock.NewMockFileSystem(seedDirectories, 0, 0)
+	dirCtrl := NewDefaultDirectoryController()
+	dirCtrl.Commands = mock.NewDirectoryCommandsForVirtualFileSystem(mockFileSystem)
+	dirCtrl.Commands.(*mock.DirectoryCommands).GetAbsolutePathFunc = func(path string) (string, error) {
+		return path, nil
+	}
+
+	expectedDir := mock.NormalizePath("/testA/testB")
+	result, err := dirCtrl.GetInitialDirectory(expectedDir)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result != expectedDir {
+		t.Errorf("Expected the initial directory to be '%s', got '%s' instead", expectedDir, result)
+	}
+}
+
+func Test_DefaultDirectoryController_GetInitialDirectory_ReturnsInvalidPathErrorWhenDirectoryDoesNotExist(t *testing.T) {
+	mockFileSystem := mock.NewMockFileSystem(mock.GetHierarchicalSeedDirectories(), 0, 0)
+	dirCtrl := NewDefaultDirectoryController()
+	dirCtrl.Commands = mock.NewDirectoryCommandsForVirtualFileSystem(mockFileSystem)
+	dirCtrl.Commands.(*mock.DirectoryCommands).GetAbsolutePathFunc = func(path string) (string, error) {
+		return path, nil
+	}
+
+	invalidDir := mock.NormalizePath("/testA/doesNotExist")
+	_, err := dirCtrl.GetInitialDirectory(invalidDir)
+
+	dErr, isDirError := err.(*DirectoryError)
+	if !isDirError {
+		t.Fatalf("Expected the error returned to be of type DirectoryError, got the following instead:\n%v\n", err)
+	}
+
+	if dErr.ErrorCode != DirInvalidPathError {
+		t.Errorf("Expected an error code of '%d', got '%d' instead", DirInvalidPathError, dErr.ErrorCode)
+	}
+
+	if !strings.Contains(dErr.Error(), invalidDir) {
+		t.Errorf("Expected the error message to contain the path '%s', got '%s' instead", invalidDir, dErr.Error())
+	}
+}
+
+func Test_DefaultDirectoryController_GetDirectoryInfo_ExcludesHiddenFilesWhenConfigured(t *testing.T) {
+	dirCtrl := NewDefaultDirectoryController()
+	dirCtrl.Commands = &mock.DirectoryCommands{
+		ReadDirectoryFunc: func(dirname string) ([]fs.FileInfo, error) {
+			return []fs.FileInfo{
+				mock.File{FileName: ".git", FileMode: fs.ModeDir | fs.ModePerm},
+				mock.File{FileName: "src", FileMode: fs.ModeDir | fs.ModePerm},
+			}, nil
+		},
+	}
+
+	output, err := dirCtrl.GetDirectoryInfo(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(output, ".git") {
+		t.Errorf("Expected hidden files to be shown by default, got the following output instead:\n%s\n", output)
+	}
+
+	dirCtrl.SetShowHidden(false)
+
+	if output, err = dirCtrl.GetDirectoryInfo("."); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(output, ".git") || !strings.Contains(output, "src") {
+		t.Errorf("Expected only visible files in output, got the following output instead:\n%s\n", output)
+	}
+}
+
+func Test_DefaultDirectoryController_ScanDirectory_SkipsIgnoredDirectoriesUnlessShown(t *testing.T) {
+	dirCtrl := NewDefaultDirectoryController()
+	dirCtrl.Ignore = NewIgnoreRules([]string{"node_modules"})
+	dirCtrl.Commands = &mock.DirectoryCommands{
+		ScanDirectoryFunc: func(path string, callback func(dirName string)) error {
+			callback("node_modules")
+			callback("src")
+			return nil
+		},
+	}
+
+	scan := func() []string {
+		var dirNames []string
+		if err := dirCtrl.ScanDirectory(mock.NormalizePath("/app"), func(dirName string) {
+			dirNames = append(dirNames, dirName)
+		}); err != nil {
+			t.Fatal(err)
+		}
+		return dirNames
+	}
+
+	if result := scan(); len(result) != 1 || result[0] != "src" {
+		t.Errorf("Expected only 'src' to be scanned, got %v instead", result)
+	}
+
+	dirCtrl.SetShowIgnored(true)
+
+	if result := scan(); len(result) != 2 {
+		t.Errorf("Expected ignored directories to be scanned when shown, got %v instead", result)
+	}
+}
+
+func Test_DefaultDirectoryController_ScanFiles_SkipsDirectoriesAndIgnoredFiles(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"src", "target"} {
+		if err := os.Mkdir(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, file := range []string{"go.mod", "debug.log", "README.md"} {
+		if err := os.WriteFile(filepath.Join(root, file), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(filepath.Join(root, "src"), filepath.Join(root, "source")); err != nil {
+		t.Skipf("Unable to create symlinks: %v", err)
+	}
+
+	dirCtrl := NewDefaultDirectoryController()
+	dirCtrl.Ignore = NewIgnoreRules([]string{"*.log"})
+
+	var fileNames []string
+	if err := dirCtrl.ScanFiles(root, func(fileName string) {
+		fileNames = append(fileNames, fileName)
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []string{"go.mod", "README.md"}; fmt.Sprint(fileNames) != fmt.Sprint(expected) {
+		t.Errorf("Expected the files %v, got %v instead", expected, fileNames)
+	}
+}
+
+func Test_DefaultDirectoryController_SearchDirectory_SkipsGitHiddenAndIgnoredDirectories(t *testing.T) {
+	dirCtrl := NewDefaultDirectoryController()
+	dirCtrl.Ignore = NewIgnoreRules([]string{"node_modules"})
+	dirCtrl.Commands = &mock.DirectoryCommands{
+		WalkDirectoryFunc: func(root string, maxDepth int, callback func(relativePath string) error) error {
+			for _, path := range []string{".git", ".cache", "node_modules", "src", filepath.Join("src", "app")} {
+				if err := callback(path); err != nil && err != filepath.SkipDir {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	search := func(options SearchOptions) []string {
+		var paths []string
+		if err := dirCtrl.SearchDirectory(mock.NormalizePath("/app"), options, func(relativePath string) bool {
+			paths = append(paths, filepath.ToSlash(relativePath))
+			return true
+		}); err != nil {
+			t.Fatal(err)
+		}
+		return paths
+	}
+
+	if result := search(SearchOptions{MaxDepth: 5, ShowHidden: true}); fmt.Sprint(result) != fmt.Sprint([]string{".cache", "src", "src/app"}) {
+		t.Errorf("Expected Git and ignored directories to be skipped, got %v instead", result)
+	}
+
+	if result := search(SearchOptions{MaxDepth: 5, ShowIgnored: true}); fmt.Sprint(result) != fmt.Sprint([]string{"node_modules", "src", "src/app"}) {
+		t.Errorf("Expected hidden directories to be skipped, got %v instead", result)
+	}
+}
+
+func Test_DefaultDirectoryController_SearchDirectory_StopsWhenCallbackReturnsFalse(t *testing.T) {
+	dirCtrl := NewDefaultDirectoryController()
+	dirCtrl.Commands = &DefaultDirectoryCommands{}
+	tempDir := t.TempDir()
+	for _, dirName := range []string{"a", "b", "c"} {
+		if err := os.Mkdir(filepath.Join(tempDir, dirName), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var paths []string
+	err := dirCtrl.SearchDirectory(tempDir, SearchOptions{MaxDepth: 5}, func(relativePath string) bool {
+		paths = append(paths, relativePath)
+		return len(paths) < 2
+	})
+
+	if err != nil {
+		t.Errorf("Expected no error when the search is stopped, got '%v' instead", err)
+	}
+	if len(paths) != 2 {
+		t.Errorf("Expected the search to stop after two directories, got %v instead", paths)
+	}
+}
@@ -0,0 +1,25 @@
+//go:build !windows
+// +build !windows
+
+package dirctrl
+
+import (
+	"os"
+	"syscall"
+)
+
+// getDevice returns the ID of the device that contains the file at the path. It returns false
+// if the ID is unavailable.
+func getDevice(path string) (uint64, bool) {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return 0, false
+	}
+
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, false
+	}
+
+	return uint64(stat.Dev), true
+}
@@ -0,0 +1,7 @@
+package dirctrl
+
+// getDevice returns the ID of the device that contains the file at the path. Device IDs are
+// unavailable on Windows, so it always returns false.
+func getDevice(string) (uint64, bool) {
+	return 0, false
+}
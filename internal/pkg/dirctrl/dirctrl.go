@@ -1,6 +1,29 @@
 // Package dirctrl is an abstraction layer for filesystem function calls.
 package dirctrl
 
-import "os"
+import (
+	"os"
+	"strings"
+)
 
 const OsPathSeparator = string(os.PathSeparator)
+
+// ExpandPath replaces a leading tilde with the home directory of the current user and
+// substitutes environment variables in the supplied path.
+func ExpandPath(path string) string {
+	path = os.ExpandEnv(path)
+
+	if path == "~" || strings.HasPrefix(path, "~/") || strings.HasPrefix(path, "~"+OsPathSeparator) {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = home + path[1:]
+		}
+	}
+
+	return path
+}
+
+// IsHidden determines if a file or directory is hidden, which is the case when its name
+// begins with a dot.
+func IsHidden(name string) bool {
+	return strings.HasPrefix(name, ".")
+}
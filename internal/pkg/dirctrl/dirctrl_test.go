@@ -0,0 +1,46 @@
+package dirctrl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_ExpandPath_ReplacesTildeWithHomeDirectory(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("Test skipped because the home directory could not be determined")
+	}
+
+	expected := filepath.Join(home, "src")
+	result := ExpandPath("~" + OsPathSeparator + "src")
+
+	if result != expected {
+		t.Errorf("Expected the path to be '%s', got '%s' instead", expected, result)
+	}
+}
+
+func Test_ExpandPath_SubstitutesEnvironmentVariables(t *testing.T) {
+	if err := os.Setenv("CI_TEST_EXPAND_PATH", "projects"); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.Unsetenv("CI_TEST_EXPAND_PATH")
+	}()
+
+	expected := OsPathSeparator + "projects" + OsPathSeparator + "ci"
+	result := ExpandPath(OsPathSeparator + "$CI_TEST_EXPAND_PATH" + OsPathSeparator + "ci")
+
+	if result != expected {
+		t.Errorf("Expected the path to be '%s', got '%s' instead", expected, result)
+	}
+}
+
+func Test_ExpandPath_DoesNotExpandTildeInsideAName(t *testing.T) {
+	expected := "~user" + OsPathSeparator + "src"
+	result := ExpandPath(expected)
+
+	if result != expected {
+		t.Errorf("Expected the path to be '%s', got '%s' instead", expected, result)
+	}
+}
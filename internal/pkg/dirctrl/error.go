@@ -3,6 +3,7 @@ package dirctrl
 const (
 	DirUnexpectedError = iota + 1
 	DirUnprivilegedError
+	DirInvalidPathError
 )
 
 // DirectoryError represents an error that occurs while accessing the filesystem.
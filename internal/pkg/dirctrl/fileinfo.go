@@ -0,0 +1,63 @@
+//go:build !windows
+// +build !windows
+
+package dirctrl
+
+import (
+	"io/fs"
+	"os/user"
+	"strconv"
+	"sync"
+	"syscall"
+)
+
+// userNames and groupNames cache the names of user and group IDs, since looking them up reads
+// the user and group databases.
+var (
+	userNames  = map[uint32]string{}
+	groupNames = map[uint32]string{}
+	namesMutex sync.Mutex
+)
+
+// getOwnership returns the names of the user and group that own the file. IDs without a name
+// are returned as numbers. It returns false if the owner is unavailable.
+func getOwnership(file fs.FileInfo) (owner, group string, ok bool) {
+	stat, ok := file.Sys().(*syscall.Stat_t)
+	if !ok {
+		return "", "", false
+	}
+
+	namesMutex.Lock()
+	defer namesMutex.Unlock()
+
+	owner, exists := userNames[stat.Uid]
+	if !exists {
+		owner = strconv.FormatUint(uint64(stat.Uid), 10)
+		if u, err := user.LookupId(owner); err == nil {
+			owner = u.Username
+		}
+		userNames[stat.Uid] = owner
+	}
+
+	group, exists = groupNames[stat.Gid]
+	if !exists {
+		group = strconv.FormatUint(uint64(stat.Gid), 10)
+		if g, err := user.LookupGroupId(group); err == nil {
+			group = g.Name
+		}
+		groupNames[stat.Gid] = group
+	}
+
+	return owner, group, true
+}
+
+// getLinksAndInode returns the number of hard links to the file and its inode number. It
+// returns false if they are unavailable.
+func getLinksAndInode(file fs.FileInfo) (links, inode uint64, ok bool) {
+	stat, ok := file.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, 0, false
+	}
+
+	return uint64(stat.Nlink), uint64(stat.Ino), true
+}
@@ -0,0 +1,15 @@
+package dirctrl
+
+import "io/fs"
+
+// getOwnership returns the names of the user and group that own the file. Owners are
+// unavailable on Windows, so it always returns false.
+func getOwnership(fs.FileInfo) (owner, group string, ok bool) {
+	return "", "", false
+}
+
+// getLinksAndInode returns the number of hard links to the file and its inode number. They are
+// unavailable on Windows, so it always returns false.
+func getLinksAndInode(fs.FileInfo) (links, inode uint64, ok bool) {
+	return 0, 0, false
+}
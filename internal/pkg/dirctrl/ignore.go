@@ -0,0 +1,210 @@
+package dirctrl
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	gitDirName        = ".git"
+	gitIgnoreFileName = ".gitignore"
+	ciIgnoreFileName  = ".ciignore"
+)
+
+// ignoreRule is a pattern in the gitignore format. Patterns that contain a slash before their
+// last character are anchored to the directory of the ignore file that contains them, and
+// other patterns match the names of directories at any depth below it.
+type ignoreRule struct {
+	baseDir  string
+	segments []string
+	anchored bool
+	negated  bool
+}
+
+// parseIgnoreRule parses a line of an ignore file whose patterns are relative to the base
+// directory. It returns false for blank lines and comments.
+func parseIgnoreRule(baseDir, line string) (ignoreRule, bool) {
+	rule := ignoreRule{baseDir: baseDir}
+
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return rule, false
+	}
+
+	if strings.HasPrefix(line, "!") {
+		rule.negated = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\`) {
+		line = line[1:]
+	}
+
+	// Note: Only directories are matched against the rules, so the trailing slash of a
+	//  directory pattern can be dropped.
+	line = strings.TrimSuffix(line, "/")
+	if line == "" {
+		return rule, false
+	}
+
+	rule.anchored = strings.Contains(line, "/")
+	rule.segments = strings.Split(strings.TrimPrefix(line, "/"), "/")
+
+	return rule, true
+}
+
+// matches determines if the rule matches the directory at the path.
+func (r ignoreRule) matches(path string) bool {
+	if !r.anchored {
+		return matchSegments(r.segments, []string{filepath.Base(path)})
+	}
+
+	var segments []string
+	if r.baseDir == "" {
+		// Note: Anchored patterns of the global ignore list match the end of the path.
+		segments = strings.Split(filepath.ToSlash(path), "/")
+		for i := range segments {
+			if matchSegments(r.segments, segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	relativePath, err := filepath.Rel(r.baseDir, path)
+	if err != nil || relativePath == ".." || strings.HasPrefix(relativePath, ".."+OsPathSeparator) {
+		return false
+	}
+
+	return matchSegments(r.segments, strings.Split(filepath.ToSlash(relativePath), "/"))
+}
+
+// matchSegments matches path segments against pattern segments, where a "**" pattern segment
+// matches any number of path segments.
+func matchSegments(pattern, path []string) bool {
+	if len(pattern) == 0 {
+		return len(path) == 0
+	}
+
+	if pattern[0] == "**" {
+		if len(pattern) == 1 {
+			return len(path) > 0
+		}
+		for i := 0; i <= len(path); i++ {
+			if matchSegments(pattern[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(path) == 0 {
+		return false
+	}
+
+	isMatch, _ := filepath.Match(pattern[0], path[0])
+
+	return isMatch && matchSegments(pattern[1:], path[1:])
+}
+
+// IgnoreRules determines which directories are ignored because they match a global list of
+// patterns, the .gitignore files of the enclosing Git repository, or the .ciignore files of
+// their parent directories. Later rules take precedence, so .ciignore files override
+// .gitignore files, which override the global patterns, and deeper files override the files
+// of their parents.
+type IgnoreRules struct {
+	global   []ignoreRule
+	mutex    sync.Mutex
+	files    map[string][]ignoreRule
+	dirRules map[string][]ignoreRule
+}
+
+// NewIgnoreRules creates a new instance of IgnoreRules with the global patterns, which use
+// the gitignore format.
+func NewIgnoreRules(patterns []string) *IgnoreRules {
+	r := &IgnoreRules{
+		files:    make(map[string][]ignoreRule),
+		dirRules: make(map[string][]ignoreRule),
+	}
+
+	for _, pattern := range patterns {
+		if rule, isRule := parseIgnoreRule("", pattern); isRule {
+			r.global = append(r.global, rule)
+		}
+	}
+
+	return r
+}
+
+// IsIgnored determines if the directory at the path is ignored.
+func (r *IgnoreRules) IsIgnored(path string) bool {
+	ignored := false
+	for _, rule := range r.getRules(filepath.Dir(path)) {
+		if rule.matches(path) {
+			ignored = !rule.negated
+		}
+	}
+
+	return ignored
+}
+
+// getRules returns the rules that apply to the entries of the directory, ordered from the
+// lowest to the highest precedence. The rules of each directory are read once.
+func (r *IgnoreRules) getRules(directory string) []ignoreRule {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if rules, exists := r.dirRules[directory]; exists {
+		return rules
+	}
+
+	var ancestors []string
+	repositoryRoot := -1
+	for dir := directory; ; dir = filepath.Dir(dir) {
+		ancestors = append(ancestors, dir)
+		if _, err := os.Stat(filepath.Join(dir, gitDirName)); err == nil && repositoryRoot < 0 {
+			repositoryRoot = len(ancestors) - 1
+		}
+		if filepath.Dir(dir) == dir {
+			break
+		}
+	}
+
+	rules := append([]ignoreRule{}, r.global...)
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		if i <= repositoryRoot {
+			rules = append(rules, r.readIgnoreFile(ancestors[i], gitIgnoreFileName)...)
+		}
+		rules = append(rules, r.readIgnoreFile(ancestors[i], ciIgnoreFileName)...)
+	}
+
+	r.dirRules[directory] = rules
+
+	return rules
+}
+
+// readIgnoreFile returns the rules of an ignore file in the directory. Missing and unreadable
+// files have no rules.
+func (r *IgnoreRules) readIgnoreFile(directory, fileName string) []ignoreRule {
+	path := filepath.Join(directory, fileName)
+	if rules, exists := r.files[path]; exists {
+		return rules
+	}
+
+	var rules []ignoreRule
+	if data, err := ioutil.ReadFile(path); err == nil {
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			if rule, isRule := parseIgnoreRule(directory, scanner.Text()); isRule {
+				rules = append(rules, rule)
+			}
+		}
+	}
+
+	r.files[path] = rules
+
+	return rules
+}
@@ -0,0 +1,89 @@
+package dirctrl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeIgnoreFileForTest(t *testing.T, path, content string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_IgnoreRules_IsIgnored_MatchesGlobalPatterns(t *testing.T) {
+	rules := NewIgnoreRules([]string{"node_modules", "__pycache__/", "*.egg-info", "vendor/cache"})
+	testCases := map[string]bool{
+		"/src/node_modules":            true,
+		"/src/app/__pycache__":         true,
+		"/src/pkg.egg-info":            true,
+		"/src/vendor/cache":            true,
+		"/src/node_modules_extra":      false,
+		"/src/cache":                   false,
+		"/src/app/vendor/cache/nested": false,
+	}
+
+	for path, expected := range testCases {
+		if result := rules.IsIgnored(filepath.FromSlash(path)); result != expected {
+			t.Errorf("Expected IsIgnored('%s') to be %v, got %v instead", path, expected, result)
+		}
+	}
+}
+
+func Test_IgnoreRules_IsIgnored_AppliesGitIgnoreFilesOfEnclosingRepository(t *testing.T) {
+	outside := t.TempDir()
+	repository := filepath.Join(outside, "repo")
+	writeIgnoreFileForTest(t, filepath.Join(outside, ".gitignore"), "src\n")
+	writeIgnoreFileForTest(t, filepath.Join(repository, ".git", "HEAD"), "ref: refs/heads/main\n")
+	writeIgnoreFileForTest(t, filepath.Join(repository, ".gitignore"), "# Build output\n/build/\ndist\n**/generated\n")
+	writeIgnoreFileForTest(t, filepath.Join(repository, "web", ".gitignore"), "!dist\n")
+
+	rules := NewIgnoreRules(nil)
+	testCases := map[string]bool{
+		filepath.Join(repository, "build"):                 true,
+		filepath.Join(repository, "web", "build"):          false,
+		filepath.Join(repository, "dist"):                  true,
+		filepath.Join(repository, "web", "dist"):           false,
+		filepath.Join(repository, "api", "x", "generated"): true,
+		filepath.Join(repository, "src"):                   false,
+	}
+
+	for path, expected := range testCases {
+		if result := rules.IsIgnored(path); result != expected {
+			t.Errorf("Expected IsIgnored('%s') to be %v, got %v instead", path, expected, result)
+		}
+	}
+}
+
+func Test_IgnoreRules_IsIgnored_AppliesCiIgnoreFilesOutsideRepositories(t *testing.T) {
+	root := t.TempDir()
+	writeIgnoreFileForTest(t, filepath.Join(root, ".ciignore"), "tmp\n")
+	writeIgnoreFileForTest(t, filepath.Join(root, "keep", ".ciignore"), "!tmp\n")
+
+	rules := NewIgnoreRules(nil)
+
+	if !rules.IsIgnored(filepath.Join(root, "tmp")) {
+		t.Error("Expected 'tmp' to be ignored")
+	}
+	if !rules.IsIgnored(filepath.Join(root, "a", "tmp")) {
+		t.Error("Expected 'a/tmp' to be ignored")
+	}
+	if rules.IsIgnored(filepath.Join(root, "keep", "tmp")) {
+		t.Error("Expected 'keep/tmp' not to be ignored")
+	}
+}
+
+func Test_IgnoreRules_IsIgnored_CiIgnoreOverridesGlobalPatterns(t *testing.T) {
+	root := t.TempDir()
+	writeIgnoreFileForTest(t, filepath.Join(root, ".ciignore"), "!target\n")
+
+	rules := NewIgnoreRules([]string{"target"})
+
+	if rules.IsIgnored(filepath.Join(root, "target")) {
+		t.Error("Expected the .ciignore file to re-include 'target'")
+	}
+}
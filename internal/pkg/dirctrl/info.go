@@ -0,0 +1,146 @@
+package dirctrl
+
+import (
+	"fmt"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/config"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/utils"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultTimeFormat = "2006-01-02 3:04 PM"
+	// unavailableValue is displayed in the columns whose values are unavailable on the platform
+	// or for the file.
+	unavailableValue = "-"
+)
+
+// defaultInfoColumns are the columns of the directory info when none are configured.
+var defaultInfoColumns = []string{
+	config.InfoColumnMode,
+	config.InfoColumnName,
+	config.InfoColumnModified,
+	config.InfoColumnBytes,
+}
+
+// infoColumnHeaders maps the columns of the directory info to their headers.
+var infoColumnHeaders = map[string]string{
+	config.InfoColumnMode:        "Mode",
+	config.InfoColumnPermissions: "Perms",
+	config.InfoColumnOwner:       "Owner",
+	config.InfoColumnGroup:       "Group",
+	config.InfoColumnLinks:       "Links",
+	config.InfoColumnInode:       "Inode",
+	config.InfoColumnName:        "Name",
+	config.InfoColumnSize:        "Size",
+	config.InfoColumnBytes:       "Bytes",
+	config.InfoColumnModified:    "ModTime",
+	config.InfoColumnCreated:     "Created",
+	config.InfoColumnTarget:      "Target",
+}
+
+// getInfoColumnValue returns the value of the column of the directory info for the file in the
+// directory.
+func (d *DefaultDirectoryController) getInfoColumnValue(column, directory string, file fs.FileInfo) string {
+	switch column {
+	case config.InfoColumnMode:
+		return file.Mode().String()
+	case config.InfoColumnPermissions:
+		return formatPermissions(file.Mode())
+	case config.InfoColumnOwner:
+		if owner, _, ok := getOwnership(file); ok {
+			return owner
+		}
+	case config.InfoColumnGroup:
+		if _, group, ok := getOwnership(file); ok {
+			return group
+		}
+	case config.InfoColumnLinks:
+		if links, _, ok := getLinksAndInode(file); ok {
+			return strconv.FormatUint(links, 10)
+		}
+	case config.InfoColumnInode:
+		if _, inode, ok := getLinksAndInode(file); ok {
+			return strconv.FormatUint(inode, 10)
+		}
+	case config.InfoColumnName:
+		return file.Name()
+	case config.InfoColumnSize:
+		return utils.FormatSize(file.Size())
+	case config.InfoColumnBytes:
+		return strconv.FormatInt(file.Size(), 10)
+	case config.InfoColumnModified:
+		return d.formatTime(file.ModTime())
+	case config.InfoColumnCreated:
+		if birthTime, ok := getBirthTime(filepath.Join(directory, file.Name())); ok {
+			return d.formatTime(birthTime)
+		}
+	case config.InfoColumnTarget:
+		if file.Mode()&fs.ModeSymlink == 0 {
+			return ""
+		}
+		if target, err := os.Readlink(filepath.Join(directory, file.Name())); err == nil {
+			return target
+		}
+	}
+
+	return unavailableValue
+}
+
+// formatTime formats the time in the time format of the directory info.
+func (d *DefaultDirectoryController) formatTime(t time.Time) string {
+	switch d.TimeFormat {
+	case "":
+		return t.Format(defaultTimeFormat)
+	case config.TimeFormatRelative:
+		return formatRelativeTime(t, time.Now())
+	default:
+		return t.Format(d.TimeFormat)
+	}
+}
+
+// formatRelativeTime returns how long before now the time was in the largest whole unit, such
+// as 3h ago. Times less than a minute ago, or in the future, are just now.
+func formatRelativeTime(t, now time.Time) string {
+	const (
+		day   = 24 * time.Hour
+		month = 30 * day
+		year  = 365 * day
+	)
+
+	elapsed := now.Sub(t)
+	switch {
+	case elapsed < time.Minute:
+		return "just now"
+	case elapsed < time.Hour:
+		return fmt.Sprintf("%dm ago", elapsed/time.Minute)
+	case elapsed < day:
+		return fmt.Sprintf("%dh ago", elapsed/time.Hour)
+	case elapsed < month:
+		return fmt.Sprintf("%dd ago", elapsed/day)
+	case elapsed < year:
+		return fmt.Sprintf("%dmo ago", elapsed/month)
+	default:
+		return fmt.Sprintf("%dy ago", elapsed/year)
+	}
+}
+
+// formatPermissions returns the permission bits of the mode in octal notation, including the
+// setuid, setgid and sticky bits, such as 0755.
+func formatPermissions(mode fs.FileMode) string {
+	permissions := uint32(mode.Perm())
+	if mode&fs.ModeSetuid != 0 {
+		permissions |= 04000
+	}
+	if mode&fs.ModeSetgid != 0 {
+		permissions |= 02000
+	}
+	if mode&fs.ModeSticky != 0 {
+		permissions |= 01000
+	}
+
+	return fmt.Sprintf("%04o", permissions)
+}
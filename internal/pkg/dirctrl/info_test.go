@@ -0,0 +1,112 @@
+package dirctrl
+
+import (
+	"github.com/goldenpathtechnologies/ci/internal/pkg/config"
+	"github.com/goldenpathtechnologies/ci/testdata/mock"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_DefaultDirectoryController_GetDirectoryInfo_DisplaysConfiguredColumns(t *testing.T) {
+	dirCtrl := NewDefaultDirectoryController()
+	dirCtrl.Columns = []string{config.InfoColumnName, config.InfoColumnPermissions, config.InfoColumnSize}
+	dirCtrl.Commands = &mock.DirectoryCommands{
+		ReadDirectoryFunc: func(dirname string) ([]fs.FileInfo, error) {
+			return []fs.FileInfo{
+				mock.File{FileName: "run.sh", FileMode: 0755 | fs.ModeSetuid, FileSize: 1536},
+			}, nil
+		},
+	}
+
+	output, err := dirCtrl.GetDirectoryInfo(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(output, "\n")
+	expected := []string{"Name", "Perms", "Size", "run.sh", "4755", "1.5 KB"}
+	if result := append(strings.Fields(lines[0]), strings.Fields(lines[2])...); strings.Join(result, " ") != strings.Join(expected, " ") {
+		t.Errorf("Expected the columns %v, got the following output instead:\n%s\n", expected, output)
+	}
+}
+
+func Test_DefaultDirectoryController_GetDirectoryInfo_DisplaysPlaceholderForUnavailableValues(t *testing.T) {
+	dirCtrl := NewDefaultDirectoryController()
+	dirCtrl.Columns = []string{config.InfoColumnName, config.InfoColumnOwner, config.InfoColumnInode}
+	dirCtrl.Commands = &mock.DirectoryCommands{
+		ReadDirectoryFunc: func(dirname string) ([]fs.FileInfo, error) {
+			return []fs.FileInfo{mock.File{FileName: "virtual"}}, nil
+		},
+	}
+
+	output, err := dirCtrl.GetDirectoryInfo(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fields := strings.Fields(strings.Split(output, "\n")[2]); strings.Join(fields, " ") != "virtual - -" {
+		t.Errorf("Expected placeholders for the owner and inode, got the following output instead:\n%s\n", output)
+	}
+}
+
+func Test_DefaultDirectoryController_GetDirectoryInfo_DisplaysOwnerAndSymlinkTarget(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Owners and symlinks are unavailable on Windows")
+	}
+
+	dir := t.TempDir()
+	if err := os.Symlink("target.txt", filepath.Join(dir, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	dirCtrl := NewDefaultDirectoryController()
+	dirCtrl.Columns = []string{config.InfoColumnName, config.InfoColumnOwner, config.InfoColumnTarget}
+
+	output, err := dirCtrl.GetDirectoryInfo(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fields := strings.Fields(strings.Split(output, "\n")[2])
+	if len(fields) != 3 || fields[0] != "link" || fields[1] == unavailableValue || fields[2] != "target.txt" {
+		t.Errorf("Expected the owner and target of the symlink, got the following output instead:\n%s\n", output)
+	}
+}
+
+func Test_DefaultDirectoryController_formatTime_UsesConfiguredLayout(t *testing.T) {
+	dirCtrl := NewDefaultDirectoryController()
+	modTime := time.Date(2021, 6, 24, 16, 53, 0, 0, time.UTC)
+
+	if result := dirCtrl.formatTime(modTime); result != "2021-06-24 4:53 PM" {
+		t.Errorf("Expected the default layout, got '%s' instead", result)
+	}
+
+	dirCtrl.TimeFormat = "02.01.2006 15:04"
+	if result := dirCtrl.formatTime(modTime); result != "24.06.2021 16:53" {
+		t.Errorf("Expected the configured layout, got '%s' instead", result)
+	}
+}
+
+func Test_formatRelativeTime_UsesLargestWholeUnit(t *testing.T) {
+	now := time.Date(2021, 6, 24, 12, 0, 0, 0, time.UTC)
+	cases := map[time.Duration]string{
+		-time.Hour:                   "just now",
+		30 * time.Second:             "just now",
+		5 * time.Minute:              "5m ago",
+		3*time.Hour + 59*time.Minute: "3h ago",
+		49 * time.Hour:               "2d ago",
+		65 * 24 * time.Hour:          "2mo ago",
+		800 * 24 * time.Hour:         "2y ago",
+	}
+
+	for age, expected := range cases {
+		if result := formatRelativeTime(now.Add(-age), now); result != expected {
+			t.Errorf("Expected '%s' for a time %v ago, got '%s' instead", expected, age, result)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package dirctrl
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Ranks of how closely a directory name matches a query fragment. Lower ranks are better.
+const (
+	matchRankExact = iota
+	matchRankExactIgnoreCase
+	matchRankBeginsWith
+	matchRankBeginsWithIgnoreCase
+	matchRankContains
+	matchRankContainsIgnoreCase
+	matchRankNone
+)
+
+// getMatchRank determines how closely the directory name matches the query fragment. Fragments
+// that contain glob characters are matched as glob patterns.
+func getMatchRank(fragment, dirName string) int {
+	if strings.ContainsAny(fragment, "*?[") {
+		if isMatch, _ := filepath.Match(fragment, dirName); isMatch {
+			return matchRankExact
+		}
+		return matchRankNone
+	}
+
+	lowerFragment := strings.ToLower(fragment)
+	lowerDirName := strings.ToLower(dirName)
+
+	switch {
+	case dirName == fragment:
+		return matchRankExact
+	case lowerDirName == lowerFragment:
+		return matchRankExactIgnoreCase
+	case strings.HasPrefix(dirName, fragment):
+		return matchRankBeginsWith
+	case strings.HasPrefix(lowerDirName, lowerFragment):
+		return matchRankBeginsWithIgnoreCase
+	case strings.Contains(dirName, fragment):
+		return matchRankContains
+	case strings.Contains(lowerDirName, lowerFragment):
+		return matchRankContainsIgnoreCase
+	default:
+		return matchRankNone
+	}
+}
+
+// splitQuery breaks the query fragments into one fragment per path segment.
+func splitQuery(fragments []string) []string {
+	var segments []string
+
+	for _, fragment := range fragments {
+		for _, segment := range strings.FieldsFunc(fragment, func(r rune) bool {
+			return r == '/' || r == '\\'
+		}) {
+			segments = append(segments, segment)
+		}
+	}
+
+	return segments
+}
+
+// FindDirectory resolves query fragments to a directory by matching each fragment against the
+// names of the child directories of the previous match, beginning at the start directory. A
+// fragment that contains path separators is matched one segment at a time. Exact matches are
+// preferred over prefix matches, which are preferred over substring matches, and case-sensitive
+// matches are preferred over case-insensitive ones. When a match leads to a dead end, the next
+// best match is tried.
+func (d *DefaultDirectoryController) FindDirectory(start string, fragments []string) (string, error) {
+	segments := splitQuery(fragments)
+	if len(segments) == 0 {
+		return "", &DirectoryError{
+			Err:       fmt.Errorf("the query must contain at least one directory name"),
+			ErrorCode: DirInvalidPathError,
+		}
+	}
+
+	if match, found := d.findDirectory(start, segments); found {
+		return match, nil
+	}
+
+	return "", &DirectoryError{
+		Err:       fmt.Errorf("no directory in '%s' matches '%s'", start, strings.Join(fragments, " ")),
+		ErrorCode: DirInvalidPathError,
+	}
+}
+
+// findDirectory performs a depth-first search for the best directory that matches all segments.
+func (d *DefaultDirectoryController) findDirectory(directory string, segments []string) (string, bool) {
+	if len(segments) == 0 {
+		return directory, true
+	}
+
+	if segments[0] == ".." {
+		return d.findDirectory(filepath.Dir(directory), segments[1:])
+	}
+
+	type candidate struct {
+		name string
+		rank int
+	}
+
+	var candidates []candidate
+	if err := d.ScanDirectory(directory, func(dirName string) {
+		if rank := getMatchRank(segments[0], dirName); rank != matchRankNone {
+			candidates = append(candidates, candidate{dirName, rank})
+		}
+	}); err != nil {
+		return "", false
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].rank < candidates[j].rank
+	})
+
+	for _, c := range candidates {
+		if match, found := d.findDirectory(filepath.Join(directory, c.name), segments[1:]); found {
+			return match, true
+		}
+	}
+
+	return "", false
+}
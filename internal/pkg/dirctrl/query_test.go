@@ -0,0 +1,95 @@
+package dirctrl
+
+import (
+	"github.com/goldenpathtechnologies/ci/testdata/mock"
+	"io/fs"
+	"testing"
+	"time"
+)
+
+func getMockDirectory(name string, children ...*mock.FileNode) *mock.FileNode {
+	return &mock.FileNode{
+		File: mock.File{
+			FileName:    name,
+			FileSize:    0,
+			FileMode:    fs.ModeDir | fs.ModePerm,
+			FileModTime: time.Now(),
+		},
+		Children: children,
+	}
+}
+
+func getDirectoryControllerForQueryTest() *DefaultDirectoryController {
+	seedDirectories := []*mock.FileNode{
+		getMockDirectory("src",
+			getMockDirectory("services-api",
+				getMockDirectory("internal")),
+			getMockDirectory("Services-web"),
+			getMockDirectory("api")),
+		getMockDirectory("docs",
+			getMockDirectory("api-reference")),
+		getMockDirectory("Docs")}
+
+	mockFileSystem := mock.NewMockFileSystem(seedDirectories, 0, 0)
+	dirCtrl := NewDefaultDirectoryController()
+	dirCtrl.Commands = mock.NewDirectoryCommandsForVirtualFileSystem(mockFileSystem)
+
+	return dirCtrl
+}
+
+func Test_DefaultDirectoryController_FindDirectory_MatchesSuccessiveChildDirectories(t *testing.T) {
+	dirCtrl := getDirectoryControllerForQueryTest()
+
+	testCases := map[string][]string{
+		"/src/services-api":          {"sr", "serv"},
+		"/src/services-api/internal": {"src/serv", "int"},
+		"/src/api":                   {"src", "api"},
+		"/src/Services-web":          {"src", "WEB"},
+		"/docs":                      {"docs"},
+		"/Docs":                      {"Docs"},
+		"/docs/api-reference":        {"d*", "api"},
+	}
+
+	for expected, fragments := range testCases {
+		expected = mock.NormalizePath(expected)
+		result, err := dirCtrl.FindDirectory(OsPathSeparator, fragments)
+
+		if err != nil {
+			t.Errorf("Expected %v to match '%s', got error '%v' instead", fragments, expected, err)
+		} else if result != expected {
+			t.Errorf("Expected %v to match '%s', got '%s' instead", fragments, expected, result)
+		}
+	}
+}
+
+func Test_DefaultDirectoryController_FindDirectory_TriesNextBestMatchWhenPathIsADeadEnd(t *testing.T) {
+	dirCtrl := getDirectoryControllerForQueryTest()
+
+	// Note: 'doc' matches 'docs' and 'Docs' before 'src', but only 'docs' has a child
+	//  that matches 'ref'.
+	expected := mock.NormalizePath("/docs/api-reference")
+	result, err := dirCtrl.FindDirectory(OsPathSeparator, []string{"DOC", "ref"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result != expected {
+		t.Errorf("Expected the match to be '%s', got '%s' instead", expected, result)
+	}
+}
+
+func Test_DefaultDirectoryController_FindDirectory_ReturnsErrorWhenNothingMatches(t *testing.T) {
+	dirCtrl := getDirectoryControllerForQueryTest()
+
+	for _, fragments := range [][]string{{"nothing"}, {"src", "internal"}, {}, {"/"}} {
+		_, err := dirCtrl.FindDirectory(OsPathSeparator, fragments)
+
+		dErr, isDirError := err.(*DirectoryError)
+		if !isDirError {
+			t.Errorf("Expected %v to return a DirectoryError, got '%v' instead", fragments, err)
+		} else if dErr.ErrorCode != DirInvalidPathError {
+			t.Errorf("Expected an error code of '%d', got '%d' instead", DirInvalidPathError, dErr.ErrorCode)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package dirctrl
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+)
+
+// errSizeStopped stops the calculation of a directory size when it is no longer needed.
+var errSizeStopped = errors.New("the size calculation was stopped")
+
+// DirectorySize is the total size of the files below a directory. Incomplete is set when some
+// of its descendants are inaccessible, so that their sizes are missing from the total.
+type DirectorySize struct {
+	Bytes      int64
+	Incomplete bool
+}
+
+// fileID identifies a file across the filesystems below a directory.
+type fileID struct {
+	device uint64
+	inode  uint64
+}
+
+// GetDirectorySize adds up the sizes of the files below the directory at the path, like du -s.
+// Symlinks are not followed, and files with several hard links are only counted once. The
+// calculation returns an error as soon as the stop channel is closed.
+func (d *DefaultDirectoryController) GetDirectorySize(path string, stop <-chan struct{}) (DirectorySize, error) {
+	var (
+		size  DirectorySize
+		links = make(map[fileID]bool)
+	)
+
+	err := filepath.WalkDir(path, func(filePath string, entry fs.DirEntry, err error) error {
+		select {
+		case <-stop:
+			return errSizeStopped
+		default:
+		}
+
+		if err != nil {
+			if filePath == path {
+				return err
+			}
+			size.Incomplete = true
+			return nil
+		}
+
+		if entry.IsDir() {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			size.Incomplete = true
+			return nil
+		}
+
+		if linkCount, inode, ok := getLinksAndInode(info); ok && linkCount > 1 {
+			device, _ := getDevice(filePath)
+			id := fileID{device, inode}
+			if links[id] {
+				return nil
+			}
+			links[id] = true
+		}
+
+		size.Bytes += info.Size()
+
+		return nil
+	})
+
+	if err != nil {
+		return DirectorySize{}, &DirectoryError{
+			Err:       err,
+			ErrorCode: DirUnexpectedError,
+		}
+	}
+
+	return size, nil
+}
@@ -0,0 +1,42 @@
+package dirctrl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_DefaultDirectoryController_GetDirectorySize_AddsUpFilesOfDescendants(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]int{"a.txt": 100, "src/b.go": 200, "src/pkg/c.go": 300}
+	for name, size := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, make([]byte, size), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Link(filepath.Join(root, "a.txt"), filepath.Join(root, "src", "a.txt")); err != nil {
+		t.Log("Hard links are unsupported, so they are not tested:", err)
+	}
+
+	size, err := NewDefaultDirectoryController().GetDirectorySize(root, make(chan struct{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if size.Bytes != 600 || size.Incomplete {
+		t.Errorf("Expected a complete size of 600 bytes, got %+v instead", size)
+	}
+}
+
+func Test_DefaultDirectoryController_GetDirectorySize_ReturnsErrorWhenStopped(t *testing.T) {
+	stop := make(chan struct{})
+	close(stop)
+
+	if _, err := NewDefaultDirectoryController().GetDirectorySize(t.TempDir(), stop); err == nil {
+		t.Error("Expected an error when the calculation is stopped")
+	}
+}
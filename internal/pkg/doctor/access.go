@@ -0,0 +1,14 @@
+//go:build !windows
+// +build !windows
+
+package doctor
+
+import "syscall"
+
+// writeAccess is the mode of access(2) that tests for permission to write.
+const writeAccess = 0x2
+
+// checkWriteAccess determines if the process may write to the file or directory at the path.
+func checkWriteAccess(path string) error {
+	return syscall.Access(path, writeAccess)
+}
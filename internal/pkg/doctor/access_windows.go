@@ -0,0 +1,21 @@
+package doctor
+
+import (
+	"errors"
+	"os"
+)
+
+// checkWriteAccess determines if the process may write to the file or directory at the path.
+// Access control lists are not examined on Windows, so only the read-only attribute is.
+func checkWriteAccess(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	if info.Mode().Perm()&0200 == 0 {
+		return errors.New("permission denied")
+	}
+
+	return nil
+}
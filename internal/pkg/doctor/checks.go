@@ -0,0 +1,308 @@
+package doctor
+
+import (
+	"fmt"
+	"github.com/gdamore/tcell/v2"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/config"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/shell"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+const (
+	minTerminalWidth  = 40
+	minTerminalHeight = 10
+)
+
+// integrationPattern finds the shell integration in a shell startup file. The first group is the
+// shell that the integration was generated for, and the second is the custom function name.
+var integrationPattern = regexp.MustCompile(`init\s+(bash|zsh|fish|sh)\b(?:[^\n]*--cmd[=\s]+([A-Za-z_][A-Za-z0-9_]*))?`)
+
+// Environment provides the parts of the environment that the checks examine so that they can
+// be replaced in tests.
+type Environment struct {
+	Getenv      func(key string) string
+	UserHomeDir func() (string, error)
+	NewScreen   func() (tcell.Screen, error)
+}
+
+// NewEnvironment returns an Environment that examines the actual environment of the process.
+func NewEnvironment() *Environment {
+	return &Environment{
+		Getenv:      os.Getenv,
+		UserHomeDir: os.UserHomeDir,
+		NewScreen:   tcell.NewScreen,
+	}
+}
+
+// getStartupFiles returns the startup files of the shell with the specified name.
+func (e *Environment) getStartupFiles(shellName, home string) []string {
+	configHome := e.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		configHome = filepath.Join(home, ".config")
+	}
+
+	switch shellName {
+	case "zsh":
+		zdotdir := e.Getenv("ZDOTDIR")
+		if zdotdir == "" {
+			zdotdir = home
+		}
+		return []string{filepath.Join(zdotdir, ".zshrc"), filepath.Join(zdotdir, ".zprofile")}
+	case "fish":
+		files := []string{filepath.Join(configHome, "fish", "config.fish")}
+		confFiles, _ := filepath.Glob(filepath.Join(configHome, "fish", "conf.d", "*.fish"))
+		return append(files, confFiles...)
+	case "bash":
+		return []string{
+			filepath.Join(home, ".bashrc"),
+			filepath.Join(home, ".bash_profile"),
+			filepath.Join(home, ".profile"),
+		}
+	default:
+		files := []string{filepath.Join(home, ".profile")}
+		if env := e.Getenv("ENV"); env != "" {
+			files = append(files, env)
+		}
+		return files
+	}
+}
+
+// CheckShellFunction determines if the shell integration is installed and which name it uses.
+func (e *Environment) CheckShellFunction() Result {
+	result := Result{Name: "Shell function"}
+
+	if functionName := e.Getenv(shell.EnvFunctionName); functionName != "" {
+		result.Status = StatusPass
+		result.Message = fmt.Sprintf("ci was run through the shell function '%s'", functionName)
+		return result
+	}
+
+	shellName := filepath.Base(e.Getenv("SHELL"))
+	home, err := e.UserHomeDir()
+	if err != nil {
+		result.Status = StatusFail
+		result.Message = "unable to search for shell startup files because the home directory is unknown"
+		return result
+	}
+
+	for _, file := range e.getStartupFiles(shellName, home) {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			continue
+		}
+
+		if matches := integrationPattern.FindSubmatch(content); matches != nil {
+			functionName := "ci"
+			if len(matches[2]) > 0 {
+				functionName = string(matches[2])
+			}
+
+			result.Status = StatusWarn
+			result.Message = fmt.Sprintf(
+				"the shell function '%s' is set up in %s, but ci was run without it. Restart your shell "+
+					"or run '%s' instead of the ci executable so that the directory can change",
+				functionName,
+				file,
+				functionName)
+			return result
+		}
+	}
+
+	result.Status = StatusFail
+	result.Message = fmt.Sprintf(
+		"the shell function was not found, so ci can print a directory but can't change to it. "+
+			"Add 'eval \"$(ci init %s)\"' to your shell startup file",
+		getSupportedShellName(shellName))
+	return result
+}
+
+// getSupportedShellName returns the shell name if ci can generate integration code for it, or
+// "sh" otherwise.
+func getSupportedShellName(shellName string) string {
+	switch shellName {
+	case "bash", "zsh", "fish":
+		return shellName
+	default:
+		return "sh"
+	}
+}
+
+// CheckLogFile determines if the log file can be written to, or created if it does not exist
+// yet. The log file is not created by the check.
+func CheckLogFile(path string, pathErr error) Result {
+	result := Result{Name: "Log file"}
+
+	if pathErr != nil {
+		result.Status = StatusFail
+		result.Message = fmt.Sprintf("unable to determine the location of the log file: %v", pathErr)
+		return result
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		if err = checkWriteAccess(path); err != nil {
+			result.Status = StatusFail
+			result.Message = fmt.Sprintf("unable to write to %s: %v", path, err)
+			return result
+		}
+
+		result.Status = StatusPass
+		result.Message = fmt.Sprintf("%s is writable", path)
+		return result
+	}
+
+	if err := checkWritableDirectory(filepath.Dir(path)); err != nil {
+		result.Status = StatusFail
+		result.Message = fmt.Sprintf("unable to create %s: %v", path, err)
+		return result
+	}
+
+	result.Status = StatusPass
+	result.Message = fmt.Sprintf("%s can be created", path)
+	return result
+}
+
+// CheckDataDirectory determines if the data directory, which holds the recently visited
+// directories that --recent lists, can be written to, or created if it does not exist yet. The
+// directory is not created by the check.
+func CheckDataDirectory(path string, pathErr error) Result {
+	result := Result{Name: "Data directory"}
+
+	if pathErr != nil {
+		result.Status = StatusFail
+		result.Message = fmt.Sprintf("unable to determine the location of the data directory, set XDG_DATA_HOME to fix this: %v", pathErr)
+		return result
+	}
+
+	// Note: The data directory is created along with its missing parents when it is first
+	//  written to, so the closest existing ancestor must be writable.
+	dir := path
+	for {
+		if _, err := os.Stat(dir); err == nil || !os.IsNotExist(err) {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	if err := checkWritableDirectory(dir); err != nil {
+		result.Status = StatusFail
+		result.Message = fmt.Sprintf("unable to write to %s: %v", path, err)
+		return result
+	}
+
+	result.Status = StatusPass
+	if dir == path {
+		result.Message = fmt.Sprintf("%s is writable", path)
+	} else {
+		result.Message = fmt.Sprintf("%s can be created", path)
+	}
+	return result
+}
+
+// checkWritableDirectory determines if the path is a directory that files can be created in.
+func checkWritableDirectory(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", path)
+	}
+
+	return checkWriteAccess(path)
+}
+
+// CheckTerminal determines if the terminal is large enough and supports colors.
+func (e *Environment) CheckTerminal() Result {
+	result := Result{Name: "Terminal"}
+
+	screen, err := e.NewScreen()
+	if err == nil {
+		err = screen.Init()
+	}
+	if err != nil {
+		result.Status = StatusFail
+		result.Message = fmt.Sprintf("unable to open the terminal: %v", err)
+		return result
+	}
+
+	width, height := screen.Size()
+	colors := screen.Colors()
+	screen.Fini()
+
+	result.Message = fmt.Sprintf("%dx%d characters, %d colors (TERM=%s)", width, height, colors, e.Getenv("TERM"))
+
+	switch {
+	case colors < 8:
+		result.Status = StatusWarn
+		result.Message += ", the terminal does not support colors"
+	case width < minTerminalWidth || height < minTerminalHeight:
+		result.Status = StatusWarn
+		result.Message += fmt.Sprintf(", at least %dx%d characters is recommended", minTerminalWidth, minTerminalHeight)
+	default:
+		result.Status = StatusPass
+	}
+
+	return result
+}
+
+// CheckHomeDirectory determines if the home directory of the user can be found.
+func (e *Environment) CheckHomeDirectory() Result {
+	result := Result{Name: "Home directory"}
+
+	home, err := e.UserHomeDir()
+	if err != nil {
+		result.Status = StatusFail
+		result.Message = fmt.Sprintf("unable to find the home directory, '~' can't be expanded: %v", err)
+		return result
+	}
+
+	if info, err := os.Stat(home); err != nil || !info.IsDir() {
+		result.Status = StatusWarn
+		result.Message = fmt.Sprintf("%s is not an accessible directory", home)
+		return result
+	}
+
+	result.Status = StatusPass
+	result.Message = home
+	return result
+}
+
+// CheckConfiguration determines if the configuration directory resolves and the configuration
+// file, if there is one, is valid.
+func (e *Environment) CheckConfiguration(override string) Result {
+	result := Result{Name: "Configuration"}
+
+	path, explicit, err := config.Path(override)
+	if err != nil {
+		result.Status = StatusFail
+		result.Message = fmt.Sprintf("unable to find the configuration directory, set XDG_CONFIG_HOME to fix this: %v", err)
+		return result
+	}
+
+	if _, err = config.Load(override); err != nil {
+		result.Status = StatusFail
+		result.Message = err.Error()
+		return result
+	}
+
+	result.Status = StatusPass
+	if _, err = os.Stat(path); os.IsNotExist(err) && !explicit {
+		result.Message = fmt.Sprintf("no configuration file at %s, using the defaults", path)
+	} else {
+		result.Message = fmt.Sprintf("loaded %s", path)
+	}
+
+	if xdg := e.Getenv("XDG_CONFIG_HOME"); xdg != "" && !filepath.IsAbs(xdg) {
+		result.Status = StatusWarn
+		result.Message += fmt.Sprintf(", XDG_CONFIG_HOME is ignored because '%s' is not an absolute path", xdg)
+	}
+
+	return result
+}
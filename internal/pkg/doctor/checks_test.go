@@ -0,0 +1,163 @@
+package doctor
+
+import (
+	"errors"
+	"github.com/gdamore/tcell/v2"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func getEnvironmentForTest(home string, variables map[string]string) *Environment {
+	return &Environment{
+		Getenv: func(key string) string {
+			return variables[key]
+		},
+		UserHomeDir: func() (string, error) {
+			return home, nil
+		},
+		NewScreen: func() (tcell.Screen, error) {
+			screen := tcell.NewSimulationScreen("")
+			return screen, nil
+		},
+	}
+}
+
+func Test_Environment_CheckShellFunction_PassesWhenRunThroughShellFunction(t *testing.T) {
+	env := getEnvironmentForTest(t.TempDir(), map[string]string{"CI_SHELL_FUNCTION": "cdi"})
+
+	result := env.CheckShellFunction()
+
+	if result.Status != StatusPass || !strings.Contains(result.Message, "'cdi'") {
+		t.Errorf("Expected the check to pass for the function 'cdi', got '%+v' instead", result)
+	}
+}
+
+func Test_Environment_CheckShellFunction_WarnsWhenFunctionIsInStartupFileButNotUsed(t *testing.T) {
+	home := t.TempDir()
+	startupFile := filepath.Join(home, ".zshrc")
+	if err := os.WriteFile(startupFile, []byte("export EDITOR=vim\neval \"$(~/.ci/bin/ci init zsh --cmd cdi)\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	env := getEnvironmentForTest(home, map[string]string{"SHELL": "/usr/bin/zsh"})
+
+	result := env.CheckShellFunction()
+
+	if result.Status != StatusWarn {
+		t.Errorf("Expected the check to warn, got '%+v' instead", result)
+	}
+
+	if !strings.Contains(result.Message, "'cdi'") || !strings.Contains(result.Message, startupFile) {
+		t.Errorf("Expected the message to name the function and startup file, got '%s' instead", result.Message)
+	}
+}
+
+func Test_Environment_CheckShellFunction_FailsWhenFunctionIsNotInstalled(t *testing.T) {
+	env := getEnvironmentForTest(t.TempDir(), map[string]string{"SHELL": "/usr/bin/fish"})
+
+	result := env.CheckShellFunction()
+
+	if result.Status != StatusFail || !strings.Contains(result.Message, "ci init fish") {
+		t.Errorf("Expected the check to fail with instructions for fish, got '%+v' instead", result)
+	}
+}
+
+func Test_CheckLogFile_FailsWhenLogFileIsNotWritable(t *testing.T) {
+	result := CheckLogFile(filepath.Join(t.TempDir(), "missing", ".log"), nil)
+	if result.Status != StatusFail {
+		t.Errorf("Expected the check to fail for a missing directory, got '%+v' instead", result)
+	}
+
+	result = CheckLogFile("", errors.New("error triggered by test"))
+	if result.Status != StatusFail {
+		t.Errorf("Expected the check to fail when the path is unknown, got '%+v' instead", result)
+	}
+
+	result = CheckLogFile(filepath.Join(t.TempDir(), ".log"), nil)
+	if result.Status != StatusPass {
+		t.Errorf("Expected the check to pass for a writable file, got '%+v' instead", result)
+	}
+}
+
+func Test_CheckLogFile_DoesNotCreateLogFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".log")
+
+	result := CheckLogFile(path, nil)
+
+	if result.Status != StatusPass || !strings.Contains(result.Message, "can be created") {
+		t.Errorf("Expected the check to pass for a log file that can be created, got '%+v' instead", result)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected the log file not to be created, got %v instead", err)
+	}
+}
+
+func Test_CheckDataDirectory_PassesWhenDirectoryCanBeCreated(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "share", "ci")
+
+	result := CheckDataDirectory(path, nil)
+
+	if result.Status != StatusPass || !strings.Contains(result.Message, "can be created") {
+		t.Errorf("Expected the check to pass for a directory that can be created, got '%+v' instead", result)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected the data directory not to be created, got %v instead", err)
+	}
+}
+
+func Test_CheckDataDirectory_FailsWhenDirectoryCannotBeCreated(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "share")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result := CheckDataDirectory(filepath.Join(file, "ci"), nil)
+	if result.Status != StatusFail {
+		t.Errorf("Expected the check to fail below a file, got '%+v' instead", result)
+	}
+
+	result = CheckDataDirectory("", errors.New("error triggered by test"))
+	if result.Status != StatusFail {
+		t.Errorf("Expected the check to fail when the path is unknown, got '%+v' instead", result)
+	}
+}
+
+func Test_Environment_CheckTerminal_ReportsTerminalSizeAndColors(t *testing.T) {
+	env := getEnvironmentForTest(t.TempDir(), map[string]string{"TERM": "xterm-256color"})
+
+	result := env.CheckTerminal()
+
+	if !strings.Contains(result.Message, "80x25") || !strings.Contains(result.Message, "TERM=xterm-256color") {
+		t.Errorf("Expected the size and terminal type to be reported, got '%s' instead", result.Message)
+	}
+}
+
+func Test_Environment_CheckTerminal_FailsWhenTerminalIsUnavailable(t *testing.T) {
+	env := getEnvironmentForTest(t.TempDir(), nil)
+	env.NewScreen = func() (tcell.Screen, error) {
+		return nil, errors.New("no terminal")
+	}
+
+	result := env.CheckTerminal()
+
+	if result.Status != StatusFail {
+		t.Errorf("Expected the check to fail, got '%+v' instead", result)
+	}
+}
+
+func Test_Environment_CheckConfiguration_FailsWhenConfigurationIsInvalid(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(configFile, []byte("{\n  \"filterMethod\": 3\n}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	env := getEnvironmentForTest(t.TempDir(), nil)
+
+	result := env.CheckConfiguration(configFile)
+
+	if result.Status != StatusFail || !strings.Contains(result.Message, ":2:") {
+		t.Errorf("Expected the check to fail with the line of the problem, got '%+v' instead", result)
+	}
+}
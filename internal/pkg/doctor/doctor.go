@@ -0,0 +1,77 @@
+// Package doctor diagnoses problems with the environment that ci depends on.
+package doctor
+
+import (
+	"fmt"
+	"io"
+)
+
+// Status is the outcome of a diagnostic check.
+type Status int
+
+const (
+	StatusPass Status = iota
+	StatusWarn
+	StatusFail
+)
+
+// String returns the label of the status that is shown in the report.
+func (s Status) String() string {
+	switch s {
+	case StatusPass:
+		return "PASS"
+	case StatusWarn:
+		return "WARN"
+	default:
+		return "FAIL"
+	}
+}
+
+// Result is the outcome of a diagnostic check along with a description of what was found.
+type Result struct {
+	Name    string
+	Status  Status
+	Message string
+}
+
+// Check is a diagnostic check that examines one part of the environment.
+type Check func() Result
+
+// Summary counts the results of each status.
+type Summary struct {
+	Passed   int
+	Warnings int
+	Failures int
+}
+
+// Run executes each check in order, writes a report of the results to the writer, and returns
+// a summary of the results.
+func Run(w io.Writer, checks []Check) (Summary, error) {
+	var summary Summary
+
+	for _, check := range checks {
+		result := check()
+
+		switch result.Status {
+		case StatusPass:
+			summary.Passed++
+		case StatusWarn:
+			summary.Warnings++
+		default:
+			summary.Failures++
+		}
+
+		if _, err := fmt.Fprintf(w, "[%v] %s: %s\n", result.Status, result.Name, result.Message); err != nil {
+			return summary, err
+		}
+	}
+
+	_, err := fmt.Fprintf(
+		w,
+		"\n%d passed, %d warnings, %d failed\n",
+		summary.Passed,
+		summary.Warnings,
+		summary.Failures)
+
+	return summary, err
+}
@@ -0,0 +1,38 @@
+package doctor
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_Run_WritesReportAndSummarizesResults(t *testing.T) {
+	var out bytes.Buffer
+	checks := []Check{
+		func() Result { return Result{Name: "First", Status: StatusPass, Message: "fine"} },
+		func() Result { return Result{Name: "Second", Status: StatusWarn, Message: "careful"} },
+		func() Result { return Result{Name: "Third", Status: StatusFail, Message: "broken"} },
+		func() Result { return Result{Name: "Fourth", Status: StatusPass, Message: "fine"} },
+	}
+
+	summary, err := Run(&out, checks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedSummary := Summary{Passed: 2, Warnings: 1, Failures: 1}
+	if summary != expectedSummary {
+		t.Errorf("Expected the summary to be '%+v', got '%+v' instead", expectedSummary, summary)
+	}
+
+	for _, expectedLine := range []string{
+		"[PASS] First: fine",
+		"[WARN] Second: careful",
+		"[FAIL] Third: broken",
+		"2 passed, 1 warnings, 1 failed",
+	} {
+		if !strings.Contains(out.String(), expectedLine) {
+			t.Errorf("Expected the report to contain '%s', got the following instead:\n%s\n", expectedLine, out.String())
+		}
+	}
+}
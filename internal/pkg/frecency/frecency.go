@@ -0,0 +1,198 @@
+// Package frecency keeps a database of the directories that the user navigates to and ranks them
+// by frecency, a combination of how frequently and how recently they were visited.
+package frecency
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/config"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/utils"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const databaseFileName = "frecency.json"
+
+const (
+	// maxTotalCount is the sum of all visit counts above which the counts are aged.
+	maxTotalCount = 1000
+	// agingFactor is the factor that all visit counts are multiplied by when they are aged.
+	agingFactor = 0.9
+	// minCount is the visit count below which aged entries are removed.
+	minCount = 1
+)
+
+// Entry is a directory in the database.
+type Entry struct {
+	Path      string  `json:"path"`
+	Count     float64 `json:"count"`
+	LastVisit int64   `json:"lastVisit"`
+}
+
+// Score calculates the frecency of the entry at the specified time. Visits within the last hour
+// count four times as much as older visits, and the weight keeps dropping with age.
+func (e *Entry) Score(now time.Time) float64 {
+	age := now.Sub(time.Unix(e.LastVisit, 0))
+
+	switch {
+	case age < time.Hour:
+		return e.Count * 4
+	case age < 24*time.Hour:
+		return e.Count * 2
+	case age < 7*24*time.Hour:
+		return e.Count / 2
+	default:
+		return e.Count / 4
+	}
+}
+
+// Store is a database of visited directories that is stored as a file. It is safe for several
+// processes to use the same Store file at once.
+type Store struct {
+	path   string
+	now    func() time.Time
+	exists func(path string) bool
+}
+
+// NewStore creates a Store that keeps its data in the specified file.
+func NewStore(path string) *Store {
+	return &Store{
+		path: path,
+		now:  time.Now,
+		exists: func(path string) bool {
+			info, err := os.Stat(path)
+			return err == nil && info.IsDir()
+		},
+	}
+}
+
+// NewDefaultStore creates a Store in the data directory of ci.
+func NewDefaultStore() (*Store, error) {
+	dataDir, err := config.DataDir()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewStore(filepath.Join(dataDir, databaseFileName)), nil
+}
+
+// Add records a visit to the directory. Entries of directories that no longer exist are pruned,
+// and visit counts are aged when their total grows too large.
+func (s *Store) Add(directory string) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("unable to create the database directory: %w", err)
+	}
+
+	release, err := utils.LockFile(s.path)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	entries, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	var (
+		updated    []Entry
+		totalCount float64
+		visited    = Entry{Path: directory}
+	)
+
+	for _, entry := range entries {
+		if entry.Path == directory {
+			visited = entry
+			continue
+		} else if !s.exists(entry.Path) {
+			continue
+		}
+
+		updated = append(updated, entry)
+		totalCount += entry.Count
+	}
+
+	// Note: The counts are aged before the visit is recorded so that the visited directory is
+	//  never dropped, even when it is visited for the first time.
+	if totalCount+visited.Count+1 > maxTotalCount {
+		updated = age(updated)
+		visited.Count *= agingFactor
+	}
+
+	visited.Count++
+	visited.LastVisit = s.now().Unix()
+	updated = append(updated, visited)
+
+	return s.write(updated)
+}
+
+// age reduces the visit count of every entry so that rarely visited directories drop out of
+// the database over time.
+func age(entries []Entry) []Entry {
+	var aged []Entry
+
+	for _, entry := range entries {
+		entry.Count *= agingFactor
+		if entry.Count >= minCount {
+			aged = append(aged, entry)
+		}
+	}
+
+	return aged
+}
+
+// Ranked returns the entries of existing directories ordered from the highest to the lowest
+// frecency.
+func (s *Store) Ranked() ([]Entry, error) {
+	entries, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	var ranked []Entry
+	for _, entry := range entries {
+		if s.exists(entry.Path) {
+			ranked = append(ranked, entry)
+		}
+	}
+
+	now := s.now()
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score(now) > ranked[j].Score(now)
+	})
+
+	return ranked, nil
+}
+
+// read loads all entries from the database file. A missing file is an empty database.
+func (s *Store) read() ([]Entry, error) {
+	data, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to read the database: %w", err)
+	}
+
+	var entries []Entry
+	if err = json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("the database '%s' is corrupt: %w", s.path, err)
+	}
+
+	return entries, nil
+}
+
+// write replaces the database file with the entries.
+func (s *Store) write(entries []Entry) error {
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return utils.WriteFileAtomic(s.path, data)
+}
@@ -0,0 +1,187 @@
+package frecency
+
+import (
+	"github.com/goldenpathtechnologies/ci/internal/pkg/utils"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func getTestStore(t *testing.T, existing ...string) (*Store, *time.Time) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	store := NewStore(filepath.Join(t.TempDir(), databaseFileName))
+	store.now = func() time.Time {
+		return now
+	}
+	store.exists = func(path string) bool {
+		for _, e := range existing {
+			if e == path {
+				return true
+			}
+		}
+		return false
+	}
+	return store, &now
+}
+
+func getRankedPaths(t *testing.T, store *Store) []string {
+	entries, err := store.Ranked()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		paths = append(paths, entry.Path)
+	}
+	return paths
+}
+
+func Test_Store_Ranked_ReturnsNothingWithoutDatabase(t *testing.T) {
+	store, _ := getTestStore(t)
+
+	if paths := getRankedPaths(t, store); len(paths) != 0 {
+		t.Errorf("expected no entries, got %v", paths)
+	}
+}
+
+func Test_Store_Add_RanksFrequentDirectoriesFirst(t *testing.T) {
+	store, _ := getTestStore(t, "/a", "/b")
+
+	for _, dir := range []string{"/a", "/b", "/b"} {
+		if err := store.Add(dir); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	paths := getRankedPaths(t, store)
+	if len(paths) != 2 || paths[0] != "/b" || paths[1] != "/a" {
+		t.Errorf("expected [/b /a], got %v", paths)
+	}
+}
+
+func Test_Store_Add_RanksRecentDirectoriesFirst(t *testing.T) {
+	store, now := getTestStore(t, "/old", "/new")
+
+	for i := 0; i < 3; i++ {
+		_ = store.Add("/old")
+	}
+	*now = now.Add(30 * 24 * time.Hour)
+	_ = store.Add("/new")
+
+	paths := getRankedPaths(t, store)
+	if len(paths) != 2 || paths[0] != "/new" {
+		t.Errorf("expected /new to rank first, got %v", paths)
+	}
+}
+
+func Test_Store_Add_PrunesDeletedDirectories(t *testing.T) {
+	store, _ := getTestStore(t, "/kept")
+
+	_ = store.Add("/deleted")
+	_ = store.Add("/kept")
+
+	entries, err := store.read()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Path != "/kept" {
+		t.Errorf("expected only /kept in the database, got %v", entries)
+	}
+}
+
+func Test_Store_Add_AgesCounts(t *testing.T) {
+	store, _ := getTestStore(t, "/frequent", "/rare")
+
+	if err := store.write([]Entry{{Path: "/frequent", Count: maxTotalCount}, {Path: "/rare", Count: 1}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = store.Add("/frequent")
+
+	entries, _ := store.read()
+	if len(entries) != 1 {
+		t.Fatalf("expected the rarely visited directory to be removed, got %v", entries)
+	}
+	if expected := maxTotalCount*agingFactor + 1; entries[0].Count != expected {
+		t.Errorf("expected count %v, got %v", expected, entries[0].Count)
+	}
+}
+
+func Test_Store_Add_KeepsNewDirectoryWhenCountsAreAged(t *testing.T) {
+	store, _ := getTestStore(t, "/frequent", "/new")
+
+	if err := store.write([]Entry{{Path: "/frequent", Count: maxTotalCount}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = store.Add("/new")
+
+	entries, _ := store.read()
+	if len(entries) != 2 || entries[1].Path != "/new" || entries[1].Count != 1 {
+		t.Fatalf("expected the visited directory to be kept with a count of 1, got %v", entries)
+	}
+	if expected := maxTotalCount * agingFactor; entries[0].Count != expected {
+		t.Errorf("expected the other counts to be aged to %v, got %v", expected, entries[0].Count)
+	}
+}
+
+func Test_Store_Add_DoesNotAgeCountsAtThreshold(t *testing.T) {
+	store, _ := getTestStore(t, "/frequent", "/new")
+
+	if err := store.write([]Entry{{Path: "/frequent", Count: maxTotalCount - 1}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = store.Add("/new")
+
+	entries, _ := store.read()
+	if len(entries) != 2 || entries[0].Count != maxTotalCount-1 || entries[1].Count != 1 {
+		t.Errorf("expected the counts to be unchanged at the threshold, got %v", entries)
+	}
+}
+
+func Test_Store_Add_IsSafeForConcurrentUse(t *testing.T) {
+	store, _ := getTestStore(t, "/dir")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := newStoreLike(store).Add("/dir"); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	entries, _ := store.read()
+	if len(entries) != 1 || entries[0].Count != 20 {
+		t.Errorf("expected a count of 20, got %v", entries)
+	}
+}
+
+func Test_Store_Add_RemovesStaleLock(t *testing.T) {
+	store, _ := getTestStore(t, "/dir")
+	lockPath := store.path + ".lock"
+
+	if err := os.WriteFile(lockPath, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	stale := time.Now().Add(-2 * utils.LockStaleAge)
+	_ = os.Chtimes(lockPath, stale, stale)
+
+	if err := store.Add("/dir"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(lockPath); !os.IsNotExist(err) {
+		t.Errorf("expected the lock to be released, got %v", err)
+	}
+}
+
+// newStoreLike creates a separate Store that uses the same file as the store, which simulates
+// another process.
+func newStoreLike(store *Store) *Store {
+	return &Store{path: store.path, now: store.now, exists: store.exists}
+}
@@ -0,0 +1,229 @@
+// Package fuzzy implements fuzzy matching of text against a pattern, in the style of fzf. A
+// pattern matches when its characters appear in the text in the same order, and matches are
+// scored so that the most relevant ones can be listed first.
+package fuzzy
+
+import (
+	"unicode"
+)
+
+const (
+	scoreMatch        = 16
+	scoreGapStart     = -3
+	scoreGapExtension = -1
+
+	// bonusBoundary is given to characters at the beginning of the text or of a word, e.g. the
+	// 'a' in 'svc-api'.
+	bonusBoundary = 8
+	// bonusCamel is given to uppercase characters that follow lowercase ones and to digits that
+	// follow letters, e.g. the 'A' in 'svcApi' or the '2' in 'v2'.
+	bonusCamel = 7
+	// bonusConsecutive is the minimum bonus of characters that directly follow the previous
+	// match. Consecutive characters also receive the bonus of the first character of their
+	// chunk if it is greater, so that matching whole words is preferred.
+	bonusConsecutive = 4
+	// bonusCase is given to characters whose case matches the pattern exactly.
+	bonusCase = 1
+	// bonusFirstCharMultiplier increases the bonus of the first character of the pattern,
+	// which matters most when the user types.
+	bonusFirstCharMultiplier = 2
+)
+
+// Match is the result of matching a pattern against text.
+type Match struct {
+	Score     int
+	Positions []int
+}
+
+// MatchText matches the pattern against the text. The positions in the returned Match are the
+// indices of the matched runes in the text. Matching ignores case unless the pattern contains
+// uppercase characters. It returns false if the pattern does not match.
+func MatchText(pattern, text string) (Match, bool) {
+	patternRunes := []rune(pattern)
+	textRunes := []rune(text)
+
+	if len(patternRunes) == 0 {
+		return Match{}, true
+	}
+
+	caseSensitive := hasUpper(patternRunes)
+	if !isSubsequence(patternRunes, textRunes, caseSensitive) {
+		return Match{}, false
+	}
+
+	return score(patternRunes, textRunes, caseSensitive), true
+}
+
+// hasUpper determines if any of the runes are uppercase.
+func hasUpper(runes []rune) bool {
+	for _, r := range runes {
+		if unicode.IsUpper(r) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// equal compares two runes, ignoring case unless caseSensitive is set.
+func equal(a, b rune, caseSensitive bool) bool {
+	if caseSensitive {
+		return a == b
+	}
+
+	return unicode.ToLower(a) == unicode.ToLower(b)
+}
+
+// isSubsequence determines if all pattern runes appear in the text in the same order.
+func isSubsequence(pattern, text []rune, caseSensitive bool) bool {
+	i := 0
+	for _, r := range text {
+		if i < len(pattern) && equal(pattern[i], r, caseSensitive) {
+			i++
+		}
+	}
+
+	return i == len(pattern)
+}
+
+// bonus calculates the bonus of matching the rune at the index of the text, based on the rune
+// before it.
+func bonus(text []rune, index int) int {
+	current := text[index]
+	if index == 0 {
+		if isWordRune(current) {
+			return bonusBoundary
+		}
+		return 0
+	}
+
+	previous := text[index-1]
+	switch {
+	case !isWordRune(previous) && isWordRune(current):
+		return bonusBoundary
+	case unicode.IsLower(previous) && unicode.IsUpper(current):
+		return bonusCamel
+	case unicode.IsLetter(previous) && unicode.IsDigit(current):
+		return bonusCamel
+	default:
+		return 0
+	}
+}
+
+// isWordRune determines if the rune is part of a word, rather than a separator such as '-',
+// '_', '.' or a space.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// maxInt returns the greater of two integers.
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+// score finds the highest scoring way to match the pattern against the text with dynamic
+// programming, and returns its score and positions. The pattern must be a subsequence of the
+// text.
+func score(pattern, text []rune, caseSensitive bool) Match {
+	const unmatched = -1 << 30
+
+	rows, cols := len(pattern), len(text)
+
+	// Note: matched[i][j] is the best score of matching pattern[:i+1] where pattern[i] matches
+	//  text[j]. best[i][j] is the best score of matching pattern[:i+1] anywhere in text[:j+1],
+	//  including the penalty of the gap after the last match. bestFrom[i][j] is the column of
+	//  the match that best[i][j] ends with, consecutive[i][j] records if matched[i][j]
+	//  continues a match at j-1, and chunkBonus[i][j] is the bonus of the first character of
+	//  the chunk of consecutive matches that matched[i][j] belongs to.
+	matched := make([][]int, rows)
+	best := make([][]int, rows)
+	bestFrom := make([][]int, rows)
+	consecutive := make([][]bool, rows)
+	chunkBonus := make([][]int, rows)
+
+	for i := 0; i < rows; i++ {
+		matched[i] = make([]int, cols)
+		best[i] = make([]int, cols)
+		bestFrom[i] = make([]int, cols)
+		consecutive[i] = make([]bool, cols)
+		chunkBonus[i] = make([]int, cols)
+
+		for j := 0; j < cols; j++ {
+			matched[i][j] = unmatched
+
+			if equal(pattern[i], text[j], caseSensitive) && j >= i {
+				charBonus := bonus(text, j)
+				caseBonus := 0
+				if pattern[i] == text[j] {
+					caseBonus = bonusCase
+				}
+
+				if i == 0 {
+					matched[i][j] = scoreMatch + charBonus*bonusFirstCharMultiplier + caseBonus
+					chunkBonus[i][j] = charBonus
+				} else if j > 0 {
+					fromGap := best[i-1][j-1]
+					if fromGap > unmatched {
+						fromGap += scoreMatch + charBonus + caseBonus
+					}
+
+					fromConsecutive := matched[i-1][j-1]
+					consecutiveBonus := maxInt(charBonus, maxInt(bonusConsecutive, chunkBonus[i-1][j-1]))
+					if fromConsecutive > unmatched {
+						fromConsecutive += scoreMatch + consecutiveBonus + caseBonus
+					}
+
+					if fromConsecutive > unmatched && fromConsecutive >= fromGap {
+						matched[i][j] = fromConsecutive
+						consecutive[i][j] = true
+						chunkBonus[i][j] = maxInt(charBonus, chunkBonus[i-1][j-1])
+					} else if fromGap > unmatched {
+						matched[i][j] = fromGap
+						chunkBonus[i][j] = charBonus
+					}
+				}
+			}
+
+			best[i][j] = matched[i][j]
+			bestFrom[i][j] = j
+			if j > 0 && best[i][j-1] > unmatched {
+				penalty := scoreGapExtension
+				if bestFrom[i][j-1] == j-1 {
+					penalty = scoreGapStart
+				}
+				if best[i][j-1]+penalty > best[i][j] {
+					best[i][j] = best[i][j-1] + penalty
+					bestFrom[i][j] = bestFrom[i][j-1]
+				}
+			}
+		}
+	}
+
+	// Note: The gap after the last match is not penalized, so the result is the best match of
+	//  the last pattern rune rather than best[rows-1][cols-1].
+	last := -1
+	for j := 0; j < cols; j++ {
+		if matched[rows-1][j] > unmatched && (last < 0 || matched[rows-1][j] > matched[rows-1][last]) {
+			last = j
+		}
+	}
+
+	positions := make([]int, rows)
+	j := last
+	for i := rows - 1; i >= 0; i-- {
+		positions[i] = j
+		if i > 0 {
+			if consecutive[i][j] {
+				j--
+			} else {
+				j = bestFrom[i-1][j-1]
+			}
+		}
+	}
+
+	return Match{Score: matched[rows-1][last], Positions: positions}
+}
@@ -0,0 +1,95 @@
+package fuzzy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_MatchText_MatchesSubsequences(t *testing.T) {
+	tests := []struct {
+		pattern, text string
+		expected      bool
+	}{
+		{"", "anything", true},
+		{"sfv2", "svc-foo-v2", true},
+		{"api", "services-api", true},
+		{"SVC", "svc-foo", false},
+		{"svc", "SVC-FOO", true},
+		{"oof", "svc-foo", false},
+		{"apis", "api", false},
+	}
+
+	for _, test := range tests {
+		if _, ok := MatchText(test.pattern, test.text); ok != test.expected {
+			t.Errorf("Expected MatchText(%q, %q) to return %v", test.pattern, test.text, test.expected)
+		}
+	}
+}
+
+func Test_MatchText_ReturnsPositionsOfMatchedRunes(t *testing.T) {
+	match, _ := MatchText("sfv2", "svc-foo-v2")
+
+	expected := []int{0, 4, 8, 9}
+	if !reflect.DeepEqual(match.Positions, expected) {
+		t.Errorf("Expected the positions %v, got %v instead", expected, match.Positions)
+	}
+}
+
+func Test_MatchText_PrefersWordBoundaries(t *testing.T) {
+	match, _ := MatchText("ba", "abc-bar")
+
+	expected := []int{4, 5}
+	if !reflect.DeepEqual(match.Positions, expected) {
+		t.Errorf("Expected the positions %v, got %v instead", expected, match.Positions)
+	}
+}
+
+func Test_MatchText_PrefersWholeWords(t *testing.T) {
+	match, _ := MatchText("fb", "foo-bar-fb")
+
+	expected := []int{8, 9}
+	if !reflect.DeepEqual(match.Positions, expected) {
+		t.Errorf("Expected the positions %v, got %v instead", expected, match.Positions)
+	}
+}
+
+func Test_MatchText_PrefersConsecutiveMatches(t *testing.T) {
+	match, _ := MatchText("api", "a-p-i-api")
+
+	expected := []int{6, 7, 8}
+	if !reflect.DeepEqual(match.Positions, expected) {
+		t.Errorf("Expected the positions %v, got %v instead", expected, match.Positions)
+	}
+}
+
+func Test_MatchText_ScoresBetterMatchesHigher(t *testing.T) {
+	tests := []struct {
+		pattern, better, worse string
+	}{
+		{"api", "api", "a-long-path-in"},
+		{"api", "services-api", "caption"},
+		{"sa", "services-api", "stash"},
+		{"fooBar", "fooBar", "foo-xbar-baR"},
+		{"doc", "Docs", "undocumented"},
+	}
+
+	for _, test := range tests {
+		better, _ := MatchText(test.pattern, test.better)
+		worse, _ := MatchText(test.pattern, test.worse)
+
+		if better.Score <= worse.Score {
+			t.Errorf("Expected '%s' (%d) to score higher than '%s' (%d) for the pattern '%s'",
+				test.better, better.Score, test.worse, worse.Score, test.pattern)
+		}
+	}
+}
+
+func Test_MatchText_GivesBonusForMatchingCase(t *testing.T) {
+	exact, _ := MatchText("docs", "docs")
+	different, _ := MatchText("docs", "DOCS")
+
+	if exact.Score <= different.Score {
+		t.Errorf("Expected an exact case match (%d) to score higher than a different case (%d)",
+			exact.Score, different.Score)
+	}
+}
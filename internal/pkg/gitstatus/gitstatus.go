@@ -0,0 +1,173 @@
+// Package gitstatus summarizes the state of the Git repository that contains a directory with
+// the local git binary. Nothing is fetched, so ahead and behind counts reflect the local refs.
+package gitstatus
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const gitDirName = ".git"
+
+// ErrTimeout is returned when git does not respond before the deadline of the context.
+var ErrTimeout = errors.New("git took too long to respond")
+
+// Status is the state of a Git repository. Branch is empty when HEAD is detached, and Head is
+// empty when the repository has no commits yet. Upstream is empty when the branch does not
+// track one, in which case Ahead and Behind are 0.
+type Status struct {
+	Branch     string
+	Head       string
+	Subject    string
+	Upstream   string
+	Ahead      int
+	Behind     int
+	Staged     int
+	Modified   int
+	Untracked  int
+	Conflicted int
+	Stashes    int
+}
+
+// IsClean determines if the work tree and index of the repository have no changes.
+func (s *Status) IsClean() bool {
+	return s.Staged == 0 && s.Modified == 0 && s.Untracked == 0 && s.Conflicted == 0
+}
+
+// FindRepository returns the root of the work tree that contains the directory, which is the
+// closest ancestor with a .git directory, or a .git file for worktrees and submodules. It
+// returns false if the directory is not inside a work tree, which includes the .git directory.
+func FindRepository(dir string) (string, bool) {
+	for {
+		if filepath.Base(dir) == gitDirName {
+			return "", false
+		}
+		if _, err := os.Stat(filepath.Join(dir, gitDirName)); err == nil {
+			return dir, true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// Read returns the status of the repository that contains the directory. The git commands are
+// killed when the context is done, and ErrTimeout is returned if its deadline passed.
+func Read(ctx context.Context, dir string) (*Status, error) {
+	output, err := run(ctx, dir, "status", "--porcelain=v2", "--branch")
+	if err != nil {
+		return nil, err
+	}
+
+	status := parseStatus(output)
+
+	if status.Head != "" {
+		if output, err = run(ctx, dir, "log", "-1", "--format=%s"); err != nil {
+			return nil, err
+		}
+		status.Subject = strings.TrimSpace(output)
+	}
+
+	if output, err = run(ctx, dir, "stash", "list"); err != nil {
+		return nil, err
+	}
+	status.Stashes = strings.Count(output, "\n")
+
+	return status, nil
+}
+
+// run runs git with the arguments in the directory and returns its output. Optional locks are
+// disabled so that reading the status does not interfere with git commands of the user.
+func run(ctx context.Context, dir string, args ...string) (string, error) {
+	var stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_OPTIONAL_LOCKS=0", "GIT_TERMINAL_PROMPT=0")
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", ErrTimeout
+	}
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return string(output), nil
+}
+
+// parseStatus reads the output of git status in the porcelain v2 format with branch headers.
+// See https://git-scm.com/docs/git-status#_porcelain_format_version_2.
+func parseStatus(output string) *Status {
+	status := &Status{}
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "#":
+			parseBranchHeader(status, fields[1:])
+		case "1", "2":
+			if len(fields) < 2 || len(fields[1]) != 2 {
+				continue
+			}
+			if fields[1][0] != '.' {
+				status.Staged++
+			}
+			if fields[1][1] != '.' {
+				status.Modified++
+			}
+		case "u":
+			status.Conflicted++
+		case "?":
+			status.Untracked++
+		}
+	}
+
+	return status
+}
+
+// parseBranchHeader reads the fields of a branch header line of git status into the status.
+func parseBranchHeader(status *Status, fields []string) {
+	if len(fields) < 2 {
+		return
+	}
+
+	switch fields[0] {
+	case "branch.oid":
+		if fields[1] != "(initial)" {
+			status.Head = fields[1]
+			if len(status.Head) > 7 {
+				status.Head = status.Head[:7]
+			}
+		}
+	case "branch.head":
+		if fields[1] != "(detached)" {
+			status.Branch = fields[1]
+		}
+	case "branch.upstream":
+		status.Upstream = fields[1]
+	case "branch.ab":
+		if len(fields) == 3 {
+			status.Ahead, _ = strconv.Atoi(strings.TrimPrefix(fields[1], "+"))
+			status.Behind, _ = strconv.Atoi(strings.TrimPrefix(fields[2], "-"))
+		}
+	}
+}
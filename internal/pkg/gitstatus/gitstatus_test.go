@@ -0,0 +1,122 @@
+package gitstatus
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func runGitForTest(t *testing.T, dir string, args ...string) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=ci", "GIT_AUTHOR_EMAIL=ci@example.com",
+		"GIT_COMMITTER_NAME=ci", "GIT_COMMITTER_EMAIL=ci@example.com",
+		"GIT_CONFIG_GLOBAL="+os.DevNull, "GIT_CONFIG_NOSYSTEM=1")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, output)
+	}
+}
+
+func writeFileForTest(t *testing.T, path, content string) {
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_parseStatus_CountsChangesAndReadsBranchHeaders(t *testing.T) {
+	output := "# branch.oid 1e4c9ba3b0c4aa9f\n" +
+		"# branch.head main\n" +
+		"# branch.upstream origin/main\n" +
+		"# branch.ab +2 -1\n" +
+		"1 M. N... 100644 100644 100644 a b staged.go\n" +
+		"1 .M N... 100644 100644 100644 a b modified.go\n" +
+		"1 MM N... 100644 100644 100644 a b both.go\n" +
+		"2 R. N... 100644 100644 100644 a b R100 new.go\told.go\n" +
+		"u UU N... 100644 100644 100644 100644 a b c conflict.go\n" +
+		"? untracked.go\n" +
+		"! ignored.log\n"
+
+	status := parseStatus(output)
+
+	expected := Status{
+		Branch: "main", Head: "1e4c9ba", Upstream: "origin/main", Ahead: 2, Behind: 1,
+		Staged: 3, Modified: 2, Untracked: 1, Conflicted: 1,
+	}
+	if *status != expected {
+		t.Errorf("Expected the status %+v, got %+v instead", expected, *status)
+	}
+}
+
+func Test_parseStatus_HandlesDetachedHeadWithoutCommits(t *testing.T) {
+	status := parseStatus("# branch.oid (initial)\n# branch.head (detached)\n")
+
+	if status.Branch != "" || status.Head != "" || !status.IsClean() {
+		t.Errorf("Expected an empty clean status, got %+v instead", *status)
+	}
+}
+
+func Test_FindRepository_FindsClosestAncestorWithGitDirectory(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "src", "pkg")
+	if err := os.MkdirAll(filepath.Join(root, gitDirName, "objects"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if result, ok := FindRepository(nested); !ok || result != root {
+		t.Errorf("Expected the repository '%s', got '%s' instead", root, result)
+	}
+	if _, ok := FindRepository(filepath.Join(root, gitDirName, "objects")); ok {
+		t.Error("Expected the .git directory to not be inside a work tree")
+	}
+}
+
+func Test_Read_ReturnsStatusOfRepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	root := t.TempDir()
+	runGitForTest(t, root, "init", "-q", "-b", "main")
+	writeFileForTest(t, filepath.Join(root, "README.md"), "hello\n")
+	runGitForTest(t, root, "add", "README.md")
+	runGitForTest(t, root, "commit", "-q", "-m", "Add the readme")
+	writeFileForTest(t, filepath.Join(root, "notes.txt"), "stash me\n")
+	runGitForTest(t, root, "stash", "-q", "-u")
+	writeFileForTest(t, filepath.Join(root, "README.md"), "hello world\n")
+	writeFileForTest(t, filepath.Join(root, "todo.txt"), "\n")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	status, err := Read(ctx, root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if status.Branch != "main" || status.Subject != "Add the readme" || len(status.Head) != 7 {
+		t.Errorf("Expected the main branch at the readme commit, got %+v instead", *status)
+	}
+	if status.Modified != 1 || status.Untracked != 1 || status.Staged != 0 || status.Stashes != 1 {
+		t.Errorf("Expected 1 modified and 1 untracked file and 1 stash, got %+v instead", *status)
+	}
+}
+
+func Test_Read_ReturnsTimeoutErrorWhenDeadlinePassed(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	if _, err := Read(ctx, t.TempDir()); err != ErrTimeout {
+		t.Errorf("Expected a timeout error, got '%v' instead", err)
+	}
+}
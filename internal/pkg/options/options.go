@@ -4,7 +4,9 @@ package options
 import (
 	"errors"
 	"fmt"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/config"
 	"github.com/jessevdk/go-flags"
+	"io"
 	"log"
 	"os"
 	"time"
@@ -20,16 +22,88 @@ type HelpOptions struct {
 	Help bool `short:"h" long:"help" description:"Show this help message"`
 }
 
+// QueryOptions defines the properties of the '--query' and '--recent' command line options.
+type QueryOptions struct {
+	Query  bool `long:"query" description:"Print the directory that matches the path fragments given as arguments instead of opening the UI"`
+	Recent bool `long:"recent" description:"Print the recently visited directories, ranked by frecency, instead of opening the UI"`
+}
+
+// OutputOptions defines the properties of the '--output-file' and '--output-fd' command line options.
+type OutputOptions struct {
+	OutputFile string `long:"output-file" value-name:"PATH" description:"Write the selected directory to a file instead of the standard output"`
+	OutputFD   int    `long:"output-fd" value-name:"N" description:"Write the selected directory to an open file descriptor instead of the standard output"`
+}
+
+// ConfigOptions defines the properties of the '--config' command line option.
+type ConfigOptions struct {
+	ConfigFile string `long:"config" value-name:"FILE" description:"Path to the configuration file (default: $XDG_CONFIG_HOME/ci/config.json, or $CI_CONFIG if set)"`
+}
+
+// InitCommand defines the properties of the 'init' command that prints the shell integration code.
+type InitCommand struct {
+	FunctionName string `long:"cmd" default:"ci" value-name:"NAME" description:"Name of the shell function that runs ci"`
+	Args         struct {
+		Shell string `positional-arg-name:"SHELL" description:"Shell to generate code for (bash, zsh, fish, sh)"`
+	} `positional-args:"yes"`
+}
+
+// QueryCommand defines the properties of the 'q' command that prints the directory matching a query.
+type QueryCommand struct {
+	Args struct {
+		Fragments []string `positional-arg-name:"FRAGMENT" description:"Partial names of each directory in the path, e.g. 'src api' for src/services-api"`
+	} `positional-args:"yes"`
+}
+
+// BookmarkAddCommand defines the properties of the 'bookmark add' command that saves a bookmark.
+type BookmarkAddCommand struct {
+	Force bool `short:"f" long:"force" description:"Replace an existing bookmark with the same name"`
+	Args  struct {
+		Name string `positional-arg-name:"NAME" description:"Name of the bookmark"`
+		Path string `positional-arg-name:"PATH" description:"Directory to bookmark (default: the current directory)"`
+	} `positional-args:"yes"`
+}
+
+// BookmarkNameCommand defines the properties of the 'bookmark' commands that operate on a single
+// bookmark, such as 'bookmark rm' and 'bookmark go'.
+type BookmarkNameCommand struct {
+	Args struct {
+		Name string `positional-arg-name:"NAME" description:"Name of the bookmark"`
+	} `positional-args:"yes"`
+}
+
+// BookmarkFileCommand defines the properties of the 'bookmark import' and 'bookmark export'
+// commands.
+type BookmarkFileCommand struct {
+	Args struct {
+		File string `positional-arg-name:"FILE" description:"File to read or write, or '-' for the standard input or output (default: -)"`
+	} `positional-args:"yes"`
+}
+
 // AppOptions stores information that is used throughout the application.
 type AppOptions struct {
 	VersionInformation *VersionOptions
 	HelpInformation    *HelpOptions
+	QueryInformation   *QueryOptions
+	OutputInformation  *OutputOptions
+	ConfigInformation  *ConfigOptions
+	Config             *config.Config
+	InitCommand        *InitCommand
+	QueryCommand       *QueryCommand
+	BookmarkAdd        *BookmarkAddCommand
+	BookmarkRemove     *BookmarkNameCommand
+	BookmarkGo         *BookmarkNameCommand
+	BookmarkImport     *BookmarkFileCommand
+	BookmarkExport     *BookmarkFileCommand
+	Command            string
+	BookmarkAction     string
 	AppName            string
 	BuildVersion       string
 	BuildDate          string
 	BuildOwner1        string
 	BuildOwner2        string
 	Repository         string
+	StartDirectory     string
+	QueryFragments     []string
 }
 
 const (
@@ -37,6 +111,25 @@ const (
 	OptionErrorUnexpected
 )
 
+const (
+	CommandInit     = "init"
+	CommandQuery    = "q"
+	CommandDoctor   = "doctor"
+	CommandBookmark = "bookmark"
+	// CommandRecent is set by the '--recent' option rather than a subcommand so that it
+	// can't be mistaken for a directory named 'recent'.
+	CommandRecent = "recent"
+)
+
+const (
+	BookmarkActionAdd    = "add"
+	BookmarkActionRemove = "rm"
+	BookmarkActionList   = "ls"
+	BookmarkActionGo     = "go"
+	BookmarkActionImport = "import"
+	BookmarkActionExport = "export"
+)
+
 // OptionError represents an error that occurred while parsing or handling command line options.
 type OptionError struct {
 	Err error
@@ -57,8 +150,20 @@ func (f *OptionError) Error() string {
 func (a *AppOptions) Init() (*AppOptions, error) {
 	a.VersionInformation = &VersionOptions{}
 	a.HelpInformation = &HelpOptions{}
+	a.ConfigInformation = &ConfigOptions{}
+	a.QueryInformation = &QueryOptions{}
+	a.OutputInformation = &OutputOptions{}
+	a.InitCommand = &InitCommand{}
+	a.QueryCommand = &QueryCommand{}
+	a.BookmarkAdd = &BookmarkAddCommand{}
+	a.BookmarkRemove = &BookmarkNameCommand{}
+	a.BookmarkGo = &BookmarkNameCommand{}
+	a.BookmarkImport = &BookmarkFileCommand{}
+	a.BookmarkExport = &BookmarkFileCommand{}
 
 	parser := flags.NewNamedParser(a.AppName, flags.PrintErrors | flags.PassDoubleDash)
+	parser.Usage = "[OPTIONS] [PATH | --query FRAGMENT...]"
+	parser.SubcommandsOptional = true
 
 	parser.UnknownOptionHandler = func(option string, arg flags.SplitArgument, args []string) ([]string, error) {
 		parser.WriteHelp(os.Stdout)
@@ -78,6 +183,27 @@ func (a *AppOptions) Init() (*AppOptions, error) {
 		return nil, err
 	}
 
+	if _, err := parser.AddGroup(
+		"Query Options",
+		"Query Options",
+		a.QueryInformation); err != nil {
+		return nil, err
+	}
+
+	if _, err := parser.AddGroup(
+		"Output Options",
+		"Output Options",
+		a.OutputInformation); err != nil {
+		return nil, err
+	}
+
+	if _, err := parser.AddGroup(
+		"Configuration",
+		"Configuration",
+		a.ConfigInformation); err != nil {
+		return nil, err
+	}
+
 	if _, err := parser.AddGroup(
 		"Help Options",
 		"Help Options",
@@ -85,7 +211,42 @@ func (a *AppOptions) Init() (*AppOptions, error) {
 		return nil, err
 	}
 
-	if _, err := parser.Parse(); err != nil {
+	if _, err := parser.AddCommand(
+		CommandInit,
+		"Print shell integration code",
+		"Prints the shell function that changes the working directory to the one selected in ci. "+
+			"Add 'eval \"$(ci init bash)\"' to your shell startup file to enable it.",
+		a.InitCommand); err != nil {
+		return nil, err
+	}
+
+	queryCommand, err := parser.AddCommand(
+		CommandQuery,
+		"Print the directory that matches a query",
+		"Prints the path of the directory that best matches the path fragments without opening the UI. "+
+			"Each fragment is matched against the names of the child directories of the previous match, "+
+			"beginning at the current directory. Exits with a non-zero code if no directory matches.",
+		a.QueryCommand)
+	if err != nil {
+		return nil, err
+	}
+	queryCommand.Aliases = []string{"query"}
+
+	if _, err := parser.AddCommand(
+		CommandDoctor,
+		"Diagnose problems with the environment",
+		"Checks the shell function, log file, terminal, home directory and configuration that ci "+
+			"depends on. Exits with a non-zero code if any check fails.",
+		&struct{}{}); err != nil {
+		return nil, err
+	}
+
+	if err := a.addBookmarkCommands(parser); err != nil {
+		return nil, err
+	}
+
+	args, err := parser.Parse()
+	if err != nil {
 		return nil, err
 	}
 
@@ -97,9 +258,216 @@ func (a *AppOptions) Init() (*AppOptions, error) {
 		return nil, err
 	}
 
+	if parser.Active != nil {
+		a.Command = parser.Active.Name
+
+		if parser.Active.Active != nil {
+			a.BookmarkAction = parser.Active.Active.Name
+		}
+	}
+
+	if a.Command == CommandBookmark {
+		if err := a.handleBookmarkCommand(); err != nil {
+			return nil, err
+		}
+	}
+
+	if a.Command == CommandQuery {
+		a.QueryFragments = append(a.QueryCommand.Args.Fragments, args...)
+	} else if a.QueryInformation.Query && a.Command == "" {
+		a.Command = CommandQuery
+		a.QueryFragments = args
+	} else if a.QueryInformation.Recent && a.Command == "" {
+		a.Command = CommandRecent
+	}
+
+	// Note: Shell integration code is generated in shell startup files, which should not
+	//  fail because of a broken configuration file. The doctor command reports these
+	//  problems itself.
+	if a.Command != CommandInit && a.Command != CommandDoctor {
+		if err := a.handleConfiguration(); err != nil {
+			return nil, err
+		}
+	}
+
+	if a.Command != "" {
+		return a, nil
+	}
+
+	if err := a.handleStartDirectory(args); err != nil {
+		return nil, err
+	}
+
 	return a, nil
 }
 
+// addBookmarkCommands adds the 'bookmark' command and its subcommands to the parser.
+func (a *AppOptions) addBookmarkCommands(parser *flags.Parser) error {
+	bookmarkCommand, err := parser.AddCommand(
+		CommandBookmark,
+		"Manage bookmarked directories",
+		"Saves, lists, and removes named bookmarks of directories, and prints the directory of a "+
+			"bookmark. Bookmarks are stored in the configuration directory of ci. Lists the "+
+			"bookmarks if no command is given.",
+		&struct{}{})
+	if err != nil {
+		return err
+	}
+	bookmarkCommand.SubcommandsOptional = true
+
+	if _, err = bookmarkCommand.AddCommand(
+		BookmarkActionAdd,
+		"Bookmark a directory",
+		"Saves a bookmark with the name NAME for the directory PATH, or the current directory if "+
+			"PATH is omitted.",
+		a.BookmarkAdd); err != nil {
+		return err
+	}
+
+	removeCommand, err := bookmarkCommand.AddCommand(
+		BookmarkActionRemove,
+		"Remove a bookmark",
+		"Removes the bookmark with the name NAME.",
+		a.BookmarkRemove)
+	if err != nil {
+		return err
+	}
+	removeCommand.Aliases = []string{"remove"}
+
+	listCommand, err := bookmarkCommand.AddCommand(
+		BookmarkActionList,
+		"List bookmarks",
+		"Lists the name and directory of every bookmark. Bookmarks of directories that no longer "+
+			"exist are marked as missing.",
+		&struct{}{})
+	if err != nil {
+		return err
+	}
+	listCommand.Aliases = []string{"list"}
+
+	if _, err = bookmarkCommand.AddCommand(
+		BookmarkActionGo,
+		"Print the directory of a bookmark",
+		"Prints the directory of the bookmark with the name NAME. The shell integration changes "+
+			"the working directory to it.",
+		a.BookmarkGo); err != nil {
+		return err
+	}
+
+	if _, err = bookmarkCommand.AddCommand(
+		BookmarkActionImport,
+		"Import bookmarks",
+		"Adds the bookmarks from a file that was written by 'bookmark export', replacing existing "+
+			"bookmarks with the same names.",
+		a.BookmarkImport); err != nil {
+		return err
+	}
+
+	if _, err = bookmarkCommand.AddCommand(
+		BookmarkActionExport,
+		"Export bookmarks",
+		"Writes all bookmarks to a file that can be read by 'bookmark import'.",
+		a.BookmarkExport); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// handleBookmarkCommand checks that the bookmark commands which require a name were given one.
+// The bookmarks are listed when no bookmark command is given.
+func (a *AppOptions) handleBookmarkCommand() error {
+	var name string
+
+	if a.BookmarkAction == "" {
+		a.BookmarkAction = BookmarkActionList
+	}
+
+	switch a.BookmarkAction {
+	case BookmarkActionAdd:
+		name = a.BookmarkAdd.Args.Name
+	case BookmarkActionRemove:
+		name = a.BookmarkRemove.Args.Name
+	case BookmarkActionGo:
+		name = a.BookmarkGo.Args.Name
+	default:
+		return nil
+	}
+
+	if name == "" {
+		return &OptionError{
+			Err:       fmt.Errorf("the 'bookmark %s' command requires a bookmark name", a.BookmarkAction),
+			ErrorCode: OptionErrorUnexpected,
+		}
+	}
+
+	return nil
+}
+
+// handleConfiguration loads the user configuration file.
+func (a *AppOptions) handleConfiguration() error {
+	var err error
+
+	if a.Config, err = config.Load(a.ConfigInformation.ConfigFile); err != nil {
+		return &OptionError{
+			Err:       err,
+			ErrorCode: OptionErrorUnexpected,
+		}
+	}
+
+	return nil
+}
+
+// OpenOutputStream opens the stream that the selected directory is written to. This is the
+// standard output unless the --output-file or --output-fd options are set.
+func (a *AppOptions) OpenOutputStream() (io.WriteCloser, error) {
+	switch {
+	case a.OutputInformation.OutputFile != "" && a.OutputInformation.OutputFD != 0:
+		return nil, errors.New("the --output-file and --output-fd options cannot be used together")
+	case a.OutputInformation.OutputFile != "":
+		file, err := os.OpenFile(a.OutputInformation.OutputFile, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open the output file: %w", err)
+		}
+		return file, nil
+	case a.OutputInformation.OutputFD > 2:
+		file := os.NewFile(uintptr(a.OutputInformation.OutputFD), "output")
+		if _, err := file.Stat(); err != nil {
+			return nil, fmt.Errorf("the output file descriptor %d is not open: %w", a.OutputInformation.OutputFD, err)
+		}
+		return file, nil
+	case a.OutputInformation.OutputFD != 0 && a.OutputInformation.OutputFD != 1:
+		return nil, fmt.Errorf("invalid output file descriptor %d", a.OutputInformation.OutputFD)
+	default:
+		return os.Stdout, nil
+	}
+}
+
+// GetConfig returns the user configuration, or the default configuration if none was loaded.
+func (a *AppOptions) GetConfig() *config.Config {
+	if a == nil || a.Config == nil {
+		return config.Default()
+	}
+
+	return a.Config
+}
+
+// handleStartDirectory sets the directory that ci starts in from the positional arguments.
+func (a *AppOptions) handleStartDirectory(args []string) error {
+	if len(args) > 1 {
+		return &OptionError{
+			Err:       fmt.Errorf("too many arguments, expected at most one path but got %d", len(args)),
+			ErrorCode: OptionErrorUnexpected,
+		}
+	}
+
+	if len(args) == 1 {
+		a.StartDirectory = args[0]
+	}
+
+	return nil
+}
+
 // handleHelpInformation prints help information and returns a graceful exit error.
 func (a *AppOptions) handleHelpInformation(parser *flags.Parser) error {
 	if a.HelpInformation.Help {
@@ -0,0 +1,27 @@
+//go:build !windows
+// +build !windows
+
+package preview
+
+import (
+	"io/fs"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+func Test_Read_DoesNotOpenNamedPipe(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events")
+	if err := syscall.Mkfifo(path, 0644); err != nil {
+		t.Skipf("Unable to create a named pipe: %v", err)
+	}
+
+	file, err := Read(path, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if file.IsRegular() || file.Mode&fs.ModeNamedPipe == 0 || len(file.Data) != 0 {
+		t.Errorf("Expected the named pipe to be described without being read, got %+v instead", file)
+	}
+}
@@ -0,0 +1,351 @@
+package preview
+
+import (
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TokenKind is the syntactic category of a Token.
+type TokenKind int
+
+const (
+	TokenText TokenKind = iota
+	TokenKeyword
+	TokenString
+	TokenComment
+	TokenNumber
+)
+
+// Token is a piece of source code. The texts of the tokens of a source file add up to its text.
+type Token struct {
+	Kind TokenKind
+	Text string
+}
+
+// language describes the syntax of a programming or configuration language well enough to
+// highlight its keywords, strings, comments and numbers.
+type language struct {
+	lineComments []string
+	blockComment [2]string
+	// quotes are the characters that delimit strings. Strings end at the end of the line
+	// unless their quote is one of the multilineQuotes.
+	quotes          string
+	multilineQuotes string
+	keywords        map[string]bool
+	ignoreCase      bool
+}
+
+var (
+	cLikeQuotes = `"'`
+	hashComment = []string{"#"}
+	cComments   = []string{"//"}
+	cBlock      = [2]string{"/*", "*/"}
+)
+
+// languages maps file extensions, and the names of files without one, to their language.
+var languages = map[string]*language{}
+
+func init() {
+	register := func(l *language, names ...string) {
+		for _, name := range names {
+			languages[name] = l
+		}
+	}
+
+	register(&language{
+		lineComments:    cComments,
+		blockComment:    cBlock,
+		quotes:          "\"'`",
+		multilineQuotes: "`",
+		keywords: newKeywords(`break case chan const continue default defer else fallthrough
+			for func go goto if import interface map package range return select struct switch
+			type var true false nil iota`),
+	}, ".go")
+
+	register(&language{
+		lineComments: cComments,
+		blockComment: cBlock,
+		quotes:       cLikeQuotes,
+		keywords: newKeywords(`auto bool break case char class const continue default define
+			delete do double else enum extern false float for goto if include inline int long
+			namespace new nullptr private protected public register return short signed sizeof
+			static struct switch template this true typedef typename union unsigned using
+			virtual void volatile while`),
+	}, ".c", ".h", ".cc", ".cpp", ".cxx", ".hpp", ".hh")
+
+	register(&language{
+		lineComments: cComments,
+		blockComment: cBlock,
+		quotes:       cLikeQuotes,
+		keywords: newKeywords(`abstract as async await base bool boolean break byte case catch
+			char class const continue data default do double else enum extends false final
+			finally float for fun if implements import in int interface internal is long
+			namespace new null object override package private protected public return sealed
+			short static string super switch this throw throws true try using val var void
+			when while`),
+	}, ".java", ".kt", ".kts", ".cs", ".scala", ".swift")
+
+	register(&language{
+		lineComments:    cComments,
+		blockComment:    cBlock,
+		quotes:          "\"'`",
+		multilineQuotes: "`",
+		keywords: newKeywords(`async await break case catch class const continue debugger
+			default delete do else enum export extends false finally for from function if
+			implements import in instanceof interface let new null of return static super
+			switch this throw true try type typeof undefined var void while with yield`),
+	}, ".js", ".jsx", ".mjs", ".cjs", ".ts", ".tsx")
+
+	register(&language{
+		lineComments: cComments,
+		blockComment: cBlock,
+		quotes:       `"`,
+		keywords: newKeywords(`as async await break const continue crate dyn else enum extern
+			false fn for if impl in let loop match mod move mut pub ref return self Self static
+			struct super trait true type unsafe use where while`),
+	}, ".rs")
+
+	register(&language{
+		lineComments: hashComment,
+		quotes:       cLikeQuotes,
+		keywords: newKeywords(`False None True and as assert async await break class continue
+			def del elif else except finally for from global if import in is lambda nonlocal
+			not or pass raise return self try while with yield`),
+	}, ".py", ".pyw")
+
+	register(&language{
+		lineComments: hashComment,
+		quotes:       cLikeQuotes,
+		keywords: newKeywords(`begin break case class def do else elsif end ensure false for
+			if in module next nil require rescue return self then true unless until when while
+			yield`),
+	}, ".rb", "Gemfile", "Rakefile")
+
+	register(&language{
+		lineComments: hashComment,
+		quotes:       cLikeQuotes,
+		keywords: newKeywords(`case do done echo elif else esac exit export fi for function if
+			in local readonly return set shift then unset until while`),
+	}, ".sh", ".bash", ".zsh", ".fish", ".bashrc", ".zshrc", ".profile", ".envrc")
+
+	register(&language{
+		lineComments: hashComment,
+		quotes:       cLikeQuotes,
+		keywords:     newKeywords(`true false null yes no on off`),
+	}, ".yaml", ".yml", ".toml", ".ini", ".cfg", ".conf", ".properties", ".env", ".gitignore",
+		".ciignore", ".dockerignore")
+
+	register(&language{
+		quotes:   `"`,
+		keywords: newKeywords(`true false null`),
+	}, ".json")
+
+	register(&language{
+		lineComments: []string{"--"},
+		blockComment: cBlock,
+		quotes:       cLikeQuotes,
+		ignoreCase:   true,
+		keywords: newKeywords(`alter and as asc by create delete desc distinct drop from group
+			having in index inner insert into is join key left limit not null on or order outer
+			primary right select set table union update values where`),
+	}, ".sql")
+
+	register(&language{
+		lineComments: hashComment,
+		quotes:       cLikeQuotes,
+		keywords: newKeywords(`FROM AS RUN CMD LABEL EXPOSE ENV ADD COPY ENTRYPOINT VOLUME USER
+			WORKDIR ARG ONBUILD STOPSIGNAL HEALTHCHECK SHELL`),
+	}, "Dockerfile", ".dockerfile")
+
+	register(&language{
+		lineComments: hashComment,
+		quotes:       cLikeQuotes,
+		keywords:     newKeywords(`define endef ifeq ifneq ifdef ifndef else endif include export`),
+	}, "Makefile", "makefile", "GNUmakefile", ".mk")
+}
+
+// newKeywords returns the set of the whitespace-separated keywords.
+func newKeywords(keywords string) map[string]bool {
+	set := make(map[string]bool)
+	for _, keyword := range strings.Fields(keywords) {
+		set[keyword] = true
+	}
+
+	return set
+}
+
+// getLanguage returns the language of the file, which is looked up by its extension, or by its
+// name if it has no known extension.
+func getLanguage(fileName string) *language {
+	name := filepath.Base(fileName)
+	if l, exists := languages[strings.ToLower(filepath.Ext(name))]; exists {
+		return l
+	}
+
+	// Note: Dotfiles such as .bashrc have no base name, so their whole name is the extension.
+	if l, exists := languages[name]; exists {
+		return l
+	}
+
+	return nil
+}
+
+// Tokenize splits the source code of the file into tokens for syntax highlighting. It returns
+// false if the language of the file is not known.
+func Tokenize(fileName, text string) ([]Token, bool) {
+	l := getLanguage(fileName)
+	if l == nil {
+		return nil, false
+	}
+
+	return l.tokenize(text), true
+}
+
+// tokenize splits the text into tokens. Text that is not a keyword, string, comment or number
+// is merged into tokens of the TokenText kind.
+func (l *language) tokenize(text string) []Token {
+	var (
+		tokens    []Token
+		textStart int
+	)
+
+	emit := func(kind TokenKind, start, end int) {
+		if textStart < start {
+			tokens = append(tokens, Token{TokenText, text[textStart:start]})
+		}
+		tokens = append(tokens, Token{kind, text[start:end]})
+		textStart = end
+	}
+
+	for i := 0; i < len(text); {
+		rest := text[i:]
+		r, size := utf8.DecodeRuneInString(rest)
+
+		if end := l.matchComment(text, i); end > i {
+			emit(TokenComment, i, end)
+			i = end
+		} else if strings.ContainsRune(l.quotes, r) {
+			end := i + size + scanString(rest[size:], r, strings.ContainsRune(l.multilineQuotes, r))
+			emit(TokenString, i, end)
+			i = end
+		} else if isDigit(r) && !isPrecededByWord(text, i) {
+			end := i + scanWord(rest)
+			emit(TokenNumber, i, end)
+			i = end
+		} else if isWordRune(r) {
+			end := i + scanWord(rest)
+			if l.isKeyword(text[i:end]) && !isPrecededByWord(text, i) {
+				emit(TokenKeyword, i, end)
+			}
+			i = end
+		} else {
+			i += size
+		}
+	}
+
+	if textStart < len(text) {
+		tokens = append(tokens, Token{TokenText, text[textStart:]})
+	}
+
+	return tokens
+}
+
+// matchComment returns the end of the comment that begins at the offset of the text, or the
+// offset if no comment begins there. Hash comments must follow whitespace, since the hash
+// character appears inside words in shell scripts.
+func (l *language) matchComment(text string, offset int) int {
+	rest := text[offset:]
+
+	if start := l.blockComment[0]; start != "" && strings.HasPrefix(rest, start) {
+		if end := strings.Index(rest[len(start):], l.blockComment[1]); end >= 0 {
+			return offset + len(start) + end + len(l.blockComment[1])
+		}
+		return len(text)
+	}
+
+	for _, marker := range l.lineComments {
+		if !strings.HasPrefix(rest, marker) {
+			continue
+		}
+		if marker == "#" && offset > 0 {
+			if r, _ := utf8.DecodeLastRuneInString(text[:offset]); !unicode.IsSpace(r) {
+				continue
+			}
+		}
+		if end := strings.IndexByte(rest, '\n'); end >= 0 {
+			return offset + end
+		}
+		return len(text)
+	}
+
+	return offset
+}
+
+// isKeyword determines if the word is a keyword of the language.
+func (l *language) isKeyword(word string) bool {
+	if l.ignoreCase {
+		word = strings.ToLower(word)
+	}
+
+	return l.keywords[word]
+}
+
+// scanString returns the length of the rest of a string up to and including its closing quote.
+// Quotes that are escaped with a backslash do not end the string, and strings end at the end
+// of the line unless they are multiline.
+func scanString(text string, quote rune, multiline bool) int {
+	escaped := false
+	for i, r := range text {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\' && quote != '`':
+			escaped = true
+		case r == quote:
+			return i + utf8.RuneLen(r)
+		case r == '\n' && !multiline:
+			return i
+		}
+	}
+
+	return len(text)
+}
+
+// scanWord returns the length of the word at the beginning of the text. Words include the
+// dots of numbers such as 1.5.
+func scanWord(text string) int {
+	for i, r := range text {
+		if !isWordRune(r) && r != '.' {
+			return i
+		}
+		if r == '.' && (i+1 >= len(text) || !isDigit(rune(text[i+1]))) {
+			return i
+		}
+	}
+
+	return len(text)
+}
+
+// isPrecededByWord determines if the character at the offset of the text continues a word,
+// such as the 2 in v2, which is therefore not a number.
+func isPrecededByWord(text string, offset int) bool {
+	if offset == 0 {
+		return false
+	}
+
+	r, _ := utf8.DecodeLastRuneInString(text[:offset])
+
+	return isWordRune(r) || r == '.' || r == '$'
+}
+
+// isWordRune determines if the character can be part of an identifier.
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// isDigit determines if the character is an ASCII digit.
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
@@ -0,0 +1,78 @@
+package preview
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func getTokenTextsForTest(tokens []Token, kind TokenKind) []string {
+	var texts []string
+	for _, token := range tokens {
+		if token.Kind == kind {
+			texts = append(texts, token.Text)
+		}
+	}
+
+	return texts
+}
+
+func Test_Tokenize_ReturnsFalseForUnknownLanguage(t *testing.T) {
+	if _, ok := Tokenize("notes.txt", "hello"); ok {
+		t.Error("Expected text files to not be tokenized")
+	}
+}
+
+func Test_Tokenize_TokensAddUpToText(t *testing.T) {
+	text := "package main\n\n// main prints.\nfunc main() {\n\tfmt.Println(\"hi\", 42)\n}\n"
+
+	tokens, ok := Tokenize("main.go", text)
+	if !ok {
+		t.Fatal("Expected Go files to be tokenized")
+	}
+
+	var result strings.Builder
+	for _, token := range tokens {
+		result.WriteString(token.Text)
+	}
+	if result.String() != text {
+		t.Errorf("Expected the tokens to add up to the text, got '%s' instead", result.String())
+	}
+}
+
+func Test_Tokenize_FindsKeywordsStringsCommentsAndNumbers(t *testing.T) {
+	text := "func main() { /* block */ x := \"a \\\" b\" + `raw` // note\n return v2 + 1.5 }"
+
+	tokens, _ := Tokenize("main.go", text)
+
+	cases := map[TokenKind][]string{
+		TokenKeyword: {"func", "return"},
+		TokenString:  {`"a \" b"`, "`raw`"},
+		TokenComment: {"/* block */", "// note"},
+		TokenNumber:  {"1.5"},
+	}
+	for kind, expected := range cases {
+		if result := getTokenTextsForTest(tokens, kind); fmt.Sprint(result) != fmt.Sprint(expected) {
+			t.Errorf("Expected the tokens %q of kind %d, got %q instead", expected, kind, result)
+		}
+	}
+}
+
+func Test_Tokenize_HashCommentsFollowWhitespace(t *testing.T) {
+	tokens, _ := Tokenize("run.sh", "echo $# # count\n")
+
+	if result := getTokenTextsForTest(tokens, TokenComment); fmt.Sprint(result) != fmt.Sprint([]string{"# count"}) {
+		t.Errorf("Expected the comment [# count], got %q instead", result)
+	}
+}
+
+func Test_Tokenize_RecognizesFilesByName(t *testing.T) {
+	tokens, ok := Tokenize("Dockerfile", "FROM golang\nRUN make\n")
+	if !ok {
+		t.Fatal("Expected Dockerfiles to be tokenized")
+	}
+
+	if result := getTokenTextsForTest(tokens, TokenKeyword); fmt.Sprint(result) != fmt.Sprint([]string{"FROM", "RUN"}) {
+		t.Errorf("Expected the keywords [FROM RUN], got %q instead", result)
+	}
+}
@@ -0,0 +1,90 @@
+// Package preview reads the beginning of files so that their contents can be previewed, and
+// splits source code into tokens for syntax highlighting.
+package preview
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"unicode/utf8"
+)
+
+// File is the beginning of a file. Data holds at most the number of bytes that were asked for,
+// and Size is the size of the whole file. Only regular files are read, so Data is empty for
+// named pipes, sockets and devices, whose Mode describes their type.
+type File struct {
+	Data     []byte
+	Size     int64
+	Mode     fs.FileMode
+	IsBinary bool
+}
+
+// IsRegular determines if the file is a regular file, whose data was read.
+func (f *File) IsRegular() bool {
+	return f.Mode.IsRegular()
+}
+
+// IsTruncated determines if the file is larger than the data that was read.
+func (f *File) IsTruncated() bool {
+	return int64(len(f.Data)) < f.Size
+}
+
+// Read reads at most maxSize bytes from the beginning of the file at the path, so that large
+// files are not read in full. Files that contain null bytes or invalid UTF-8 are binary. Files
+// that are not regular files are not opened, since opening a named pipe blocks until it has a
+// writer.
+func Read(path string, maxSize int) (*File, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read '%s' for preview: %w", path, err)
+	}
+	if !info.Mode().IsRegular() {
+		return &File{Size: info.Size(), Mode: info.Mode()}, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open '%s' for preview: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	size := int64(maxSize)
+	if info.Size() < size {
+		size = info.Size()
+	}
+
+	data := make([]byte, size)
+	n, err := io.ReadFull(file, data)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, fmt.Errorf("unable to read '%s' for preview: %w", path, err)
+	}
+	data = data[:n]
+
+	return &File{
+		Data:     data,
+		Size:     info.Size(),
+		Mode:     info.Mode(),
+		IsBinary: isBinary(data, int64(n) < info.Size()),
+	}, nil
+}
+
+// isBinary determines if the data is the beginning of a binary file. When the data is
+// truncated, the last character may be incomplete, so it is not checked.
+func isBinary(data []byte, isTruncated bool) bool {
+	if bytes.IndexByte(data, 0) >= 0 {
+		return true
+	}
+
+	if isTruncated {
+		for i := 0; i < utf8.UTFMax-1 && len(data) > 0; i++ {
+			if r, _ := utf8.DecodeLastRune(data); r != utf8.RuneError {
+				break
+			}
+			data = data[:len(data)-1]
+		}
+	}
+
+	return !utf8.Valid(data)
+}
@@ -0,0 +1,75 @@
+package preview
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFileForTest(t *testing.T, name string, data []byte) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func Test_Read_ReadsWholeSmallFile(t *testing.T) {
+	path := writeFileForTest(t, "notes.txt", []byte("hello\nworld\n"))
+
+	file, err := Read(path, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(file.Data) != "hello\nworld\n" || file.Size != 12 || file.IsTruncated() || file.IsBinary {
+		t.Errorf("Expected the whole text file to be read, got %+v instead", file)
+	}
+}
+
+func Test_Read_ReadsOnlyBeginningOfLargeFile(t *testing.T) {
+	path := writeFileForTest(t, "large.log", []byte(strings.Repeat("line\n", 1000)))
+
+	file, err := Read(path, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(file.Data) != 100 || file.Size != 5000 || !file.IsTruncated() {
+		t.Errorf("Expected 100 of 5000 bytes to be read, got %d of %d instead", len(file.Data), file.Size)
+	}
+}
+
+func Test_Read_DetectsBinaryFile(t *testing.T) {
+	path := writeFileForTest(t, "image.png", []byte{0x89, 'P', 'N', 'G', 0x0d, 0x0a, 0x1a, 0x0a, 0x00, 0x00})
+
+	file, err := Read(path, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !file.IsBinary {
+		t.Error("Expected the file to be binary")
+	}
+}
+
+func Test_Read_IgnoresCharacterCutOffAtLimit(t *testing.T) {
+	path := writeFileForTest(t, "umlauts.txt", []byte(strings.Repeat("ä", 10)))
+
+	file, err := Read(path, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if file.IsBinary {
+		t.Error("Expected the text file to not be binary when a character is cut off")
+	}
+}
+
+func Test_Read_ReturnsErrorForMissingFile(t *testing.T) {
+	if _, err := Read(filepath.Join(t.TempDir(), "missing"), 1024); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
@@ -0,0 +1,204 @@
+// Package shell generates the shell integration code that allows ci to change the working
+// directory of the shell it is run from.
+package shell
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+const DefaultFunctionName = "ci"
+
+// EnvFunctionName is the environment variable that the shell function sets to its own name
+// when it runs ci.
+const EnvFunctionName = "CI_SHELL_FUNCTION"
+
+// functionNamePattern restricts function names to those that are valid in every supported shell.
+var functionNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ExitCodes are the exit codes of ci that the shell integration responds to.
+type ExitCodes struct {
+	Selected    int
+	Interrupted int
+}
+
+// Integration holds the data that is substituted into a shell integration template.
+type Integration struct {
+	Shell        string
+	FunctionName string
+	Executable   string
+	ExitCodes    ExitCodes
+}
+
+// posixTemplate is the shell function for bash, zsh, and POSIX compliant shells.
+const posixTemplate = `# ci shell integration for {{.Shell}}. Add the following line to your shell
+# startup file to enable it:
+#
+#   eval "$({{quote .Executable}} init {{.Shell}}{{if ne .FunctionName "ci"}} --cmd {{.FunctionName}}{{end}})"
+#
+{{.FunctionName}}() {
+  for __ci_arg in "$@"; do
+    case "$__ci_arg" in
+      -h|--help|-v|--version|--recent)
+        unset __ci_arg
+        CI_SHELL_FUNCTION={{.FunctionName}} {{quote .Executable}} "$@"
+        return
+        ;;
+    esac
+  done
+  unset __ci_arg
+
+  # Note: Only 'bookmark go' prints a directory to change to, the other bookmark commands
+  #  print listings that must reach the standard output unchanged.
+  case "$1" in
+    init|doctor|bookmark)
+      if [ "$1 $2" != "bookmark go" ]; then
+        CI_SHELL_FUNCTION={{.FunctionName}} {{quote .Executable}} "$@"
+        return
+      fi
+      ;;
+  esac
+
+  # Note: ci writes the selected directory to file descriptor 3, and its standard output is
+  #  redirected to the standard error stream so that stray output can't corrupt the path.
+  __ci_output=$(CI_SHELL_FUNCTION={{.FunctionName}} {{quote .Executable}} --output-fd 3 "$@" 3>&1 1>&2)
+  __ci_code=$?
+
+  case "$__ci_code" in
+    {{.ExitCodes.Selected}})
+      # Note: Commands such as 'ci --config FILE bookmark ls' succeed without selecting a
+      #  directory, so there may be nothing to change to.
+      if [ -n "$__ci_output" ]; then
+        cd -- "$__ci_output" || __ci_code=$?
+      fi
+      ;;
+    {{.ExitCodes.Interrupted}})
+      echo "Program forcefully exited" >&2
+      ;;
+  esac
+
+  # Note: The exit code is moved to the positional parameters so that no variables of the
+  #  function are left in the shell.
+  set -- "$__ci_code"
+  unset __ci_output __ci_code
+  return "$1"
+}
+`
+
+// fishTemplate is the shell function for fish.
+const fishTemplate = `# ci shell integration for fish. Add the following line to
+# ~/.config/fish/config.fish to enable it:
+#
+#   {{fishQuote .Executable}} init fish{{if ne .FunctionName "ci"}} --cmd {{.FunctionName}}{{end}} | source
+#
+function {{.FunctionName}} --description 'Interactive cd'
+    set -lx CI_SHELL_FUNCTION {{.FunctionName}}
+
+    for arg in $argv
+        switch $arg
+            case -h --help -v --version --recent
+                {{fishQuote .Executable}} $argv
+                return
+        end
+    end
+
+    # Note: Only 'bookmark go' prints a directory to change to, the other bookmark commands
+    #  print listings that must reach the standard output unchanged.
+    if contains -- "$argv[1]" init doctor bookmark; and test "$argv[1] $argv[2]" != "bookmark go"
+        {{fishQuote .Executable}} $argv
+        return
+    end
+
+    # Note: ci writes the selected directory to file descriptor 3, and its standard output is
+    #  redirected to the standard error stream so that stray output can't corrupt the path.
+    set -l output ({{fishQuote .Executable}} --output-fd 3 $argv 3>&1 1>&2)
+    set -l code $status
+
+    switch $code
+        case {{.ExitCodes.Selected}}
+            # Note: Commands such as 'ci --config FILE bookmark ls' succeed without selecting
+            #  a directory, and cd without arguments would change to the home directory.
+            if test -n "$output"
+                cd $output
+                or set code $status
+            end
+        case {{.ExitCodes.Interrupted}}
+            echo "Program forcefully exited" >&2
+    end
+
+    return $code
+end
+`
+
+var templates = map[string]string{
+	"bash": posixTemplate,
+	"zsh":  posixTemplate,
+	"sh":   posixTemplate,
+	"fish": fishTemplate,
+}
+
+var templateFuncs = template.FuncMap{
+	"quote":     quotePosix,
+	"fishQuote": quoteFish,
+}
+
+// SupportedShells returns the names of the shells that ci can generate integration code for.
+func SupportedShells() []string {
+	var shells []string
+	for name := range templates {
+		shells = append(shells, name)
+	}
+	sort.Strings(shells)
+
+	return shells
+}
+
+// Generate returns the shell integration code for the Integration's shell.
+func (i *Integration) Generate() (string, error) {
+	if i.Shell == "" {
+		return "", fmt.Errorf(
+			"no shell specified, expected one of: %s",
+			strings.Join(SupportedShells(), ", "))
+	}
+
+	text, exists := templates[i.Shell]
+	if !exists {
+		return "", fmt.Errorf(
+			"unsupported shell '%s', expected one of: %s",
+			i.Shell,
+			strings.Join(SupportedShells(), ", "))
+	}
+
+	if !functionNamePattern.MatchString(i.FunctionName) {
+		return "", fmt.Errorf(
+			"invalid function name '%s', names may only contain letters, digits, and underscores",
+			i.FunctionName)
+	}
+
+	tmpl, err := template.New(i.Shell).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buffer bytes.Buffer
+	if err = tmpl.Execute(&buffer, i); err != nil {
+		return "", err
+	}
+
+	return buffer.String(), nil
+}
+
+// quotePosix wraps text in single quotes so that POSIX shells treat it literally.
+func quotePosix(text string) string {
+	return "'" + strings.ReplaceAll(text, "'", `'\''`) + "'"
+}
+
+// quoteFish wraps text in single quotes so that fish treats it literally.
+func quoteFish(text string) string {
+	text = strings.ReplaceAll(text, `\`, `\\`)
+	return "'" + strings.ReplaceAll(text, "'", `\'`) + "'"
+}
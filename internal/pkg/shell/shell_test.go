@@ -0,0 +1,204 @@
+package shell
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func Test_Integration_Generate_ReturnsFunctionForEachSupportedShell(t *testing.T) {
+	for _, shellName := range SupportedShells() {
+		integration := &Integration{
+			Shell:        shellName,
+			FunctionName: DefaultFunctionName,
+			Executable:   "/opt/ci/bin/ci",
+		}
+
+		script, err := integration.Generate()
+		if err != nil {
+			t.Errorf("Expected no error for shell '%s', got '%v' instead", shellName, err)
+			continue
+		}
+
+		if !strings.Contains(script, "'/opt/ci/bin/ci'") {
+			t.Errorf("Expected the script for shell '%s' to run the quoted executable path, got:\n%s\n", shellName, script)
+		}
+	}
+}
+
+func Test_Integration_Generate_UsesCustomFunctionName(t *testing.T) {
+	expectedDeclarations := map[string]string{
+		"bash": "cdi() {",
+		"zsh":  "cdi() {",
+		"sh":   "cdi() {",
+		"fish": "function cdi ",
+	}
+
+	for shellName, expected := range expectedDeclarations {
+		integration := &Integration{Shell: shellName, FunctionName: "cdi", Executable: "ci"}
+
+		script, err := integration.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.Contains(script, expected) {
+			t.Errorf("Expected the script for shell '%s' to contain '%s', got:\n%s\n", shellName, expected, script)
+		}
+
+		if !strings.Contains(script, "--cmd cdi") {
+			t.Errorf("Expected the usage instructions for shell '%s' to include the --cmd option", shellName)
+		}
+	}
+}
+
+func Test_Integration_Generate_ReturnsErrorForUnsupportedShell(t *testing.T) {
+	for _, shellName := range []string{"", "pwsh"} {
+		integration := &Integration{Shell: shellName, FunctionName: DefaultFunctionName, Executable: "ci"}
+
+		if _, err := integration.Generate(); err == nil {
+			t.Errorf("Expected an error for shell '%s'", shellName)
+		}
+	}
+}
+
+func Test_Integration_Generate_ReturnsErrorForInvalidFunctionName(t *testing.T) {
+	for _, name := range []string{"", "1ci", "c i", "ci;rm", "c-i"} {
+		integration := &Integration{Shell: "bash", FunctionName: name, Executable: "ci"}
+
+		if _, err := integration.Generate(); err == nil {
+			t.Errorf("Expected an error for function name '%s'", name)
+		}
+	}
+}
+
+func Test_quotePosix_EscapesSingleQuotes(t *testing.T) {
+	expected := `'/home/o'\''brien/ci'`
+	result := quotePosix("/home/o'brien/ci")
+
+	if result != expected {
+		t.Errorf("Expected '%s', got '%s' instead", expected, result)
+	}
+}
+
+func Test_quoteFish_EscapesSingleQuotesAndBackslashes(t *testing.T) {
+	expected := `'C:\\o\'brien\\ci'`
+	result := quoteFish(`C:\o'brien\ci`)
+
+	if result != expected {
+		t.Errorf("Expected '%s', got '%s' instead", expected, result)
+	}
+}
+
+func Test_Integration_Generate_ReadsSelectedDirectoryFromOutputFileDescriptor(t *testing.T) {
+	for _, shellName := range SupportedShells() {
+		integration := &Integration{
+			Shell:        shellName,
+			FunctionName: DefaultFunctionName,
+			Executable:   "ci",
+			ExitCodes:    ExitCodes{Selected: 0, Interrupted: 2},
+		}
+
+		script, err := integration.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.Contains(script, "--output-fd 3") || !strings.Contains(script, "3>&1 1>&2") {
+			t.Errorf("Expected the script for shell '%s' to capture file descriptor 3, got:\n%s\n", shellName, script)
+		}
+	}
+}
+
+func Test_Integration_Generate_ChangesDirectoryOnlyForBookmarkGoCommand(t *testing.T) {
+	for _, shellName := range SupportedShells() {
+		integration := &Integration{
+			Shell:        shellName,
+			FunctionName: DefaultFunctionName,
+			Executable:   "/opt/ci/bin/ci",
+		}
+
+		script, err := integration.Generate()
+		if err != nil {
+			t.Fatalf("Unexpected error for shell '%s': %v", shellName, err)
+		}
+
+		if !strings.Contains(script, "bookmark go") {
+			t.Errorf("Expected the %s script to treat 'bookmark go' differently from other bookmark commands", shellName)
+		}
+	}
+}
+
+func Test_Integration_Generate_StaysInDirectoryWhenNothingIsSelected(t *testing.T) {
+	shellPath, err := exec.LookPath("sh")
+	if err != nil || runtime.GOOS == "windows" {
+		t.Skip("A POSIX shell is unavailable")
+	}
+
+	dir := t.TempDir()
+	executable := filepath.Join(dir, "ci")
+	if err = os.WriteFile(executable, []byte("#!/bin/sh\nexit 0\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	integration := &Integration{
+		Shell:        "sh",
+		FunctionName: DefaultFunctionName,
+		Executable:   executable,
+		ExitCodes:    ExitCodes{Selected: 0, Interrupted: 2},
+	}
+	script, err := integration.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Note: Some shells ignore an empty directory, so cd is replaced to record that it was called.
+	cmd := exec.Command(shellPath, "-c", "cd() { echo cd; }\n"+script+"\nci --config x bookmark ls\necho \"$? $PWD\"")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := "0 " + dir; strings.TrimSpace(string(output)) != expected {
+		t.Errorf("Expected '%s', got '%s' instead", expected, strings.TrimSpace(string(output)))
+	}
+}
+
+func Test_Integration_Generate_LeavesNoVariablesInShell(t *testing.T) {
+	shellPath, err := exec.LookPath("sh")
+	if err != nil || runtime.GOOS == "windows" {
+		t.Skip("A POSIX shell is unavailable")
+	}
+
+	dir := t.TempDir()
+	executable := filepath.Join(dir, "ci")
+	if err = os.WriteFile(executable, []byte("#!/bin/sh\nexit 3\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	integration := &Integration{
+		Shell:        "sh",
+		FunctionName: DefaultFunctionName,
+		Executable:   executable,
+		ExitCodes:    ExitCodes{Selected: 0, Interrupted: 2},
+	}
+	script, err := integration.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(shellPath, "-c", script+"\nci src\necho \"$? ${__ci_arg-x}${__ci_output-x}${__ci_code-x}\"")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := "3 xxx"; strings.TrimSpace(string(output)) != expected {
+		t.Errorf("Expected '%s', got '%s' instead", expected, strings.TrimSpace(string(output)))
+	}
+}
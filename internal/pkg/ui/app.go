@@ -13,6 +13,14 @@ const (
 	bufferExitSequence  = "\033[?1049l"
 )
 
+// Exit codes of the program. Shell integrations depend on these values, so they must not change.
+const (
+	ExitCodeSelected    = 0
+	ExitCodeError       = 1
+	ExitCodeInterrupted = 2
+	ExitCodeCancelled   = 3
+)
+
 // App is an abstraction of the tview.Application with additional functionality.
 type App struct {
 	*tview.Application
@@ -21,6 +29,8 @@ type App struct {
 	errorStream        io.Writer
 	handleNormalExit   func()
 	handleErrorExit    func()
+	handleCancelExit   func()
+	handleInterrupt    func()
 	// TODO: Add a flag that enables/disables logging throughout the app so that
 	//  it is handled consistently. I discovered during testing that I have to assume
 	//  the SUT enabled logging to determine where error output is received. It would
@@ -37,15 +47,22 @@ func NewApp(screen tcell.Screen, stream io.Writer, errStream io.Writer) *App {
 		outputStream:       stream,
 		errorStream:        errStream,
 		handleNormalExit: func() {
-			os.Exit(0)
+			os.Exit(ExitCodeSelected)
 		},
 		handleErrorExit: func() {
-			os.Exit(1)
+			os.Exit(ExitCodeError)
+		},
+		handleCancelExit: func() {
+			os.Exit(ExitCodeCancelled)
+		},
+		handleInterrupt: func() {
+			os.Exit(ExitCodeInterrupted)
 		},
 	}
 }
 
-// PrintAndExit prints data to the App's configured output stream and exits the program.
+// PrintAndExit prints the selected path to the App's configured output stream and exits the
+// program with ExitCodeSelected.
 func (a *App) PrintAndExit(data string) {
 	a.Stop()
 	_, err := a.outputStream.Write([]byte(data))
@@ -53,7 +70,20 @@ func (a *App) PrintAndExit(data string) {
 	a.handleNormalExit()
 }
 
-// HandleError logs errors and gracefully exits the program with a code of 1.
+// CancelAndExit exits the program with ExitCodeCancelled without printing a path.
+func (a *App) CancelAndExit() {
+	a.Stop()
+	a.handleCancelExit()
+}
+
+// InterruptAndExit exits the program with ExitCodeInterrupted without printing a path. This
+// happens when the user presses Ctrl+C or the program receives an interrupt signal.
+func (a *App) InterruptAndExit() {
+	a.Stop()
+	a.handleInterrupt()
+}
+
+// HandleError logs errors and gracefully exits the program with ExitCodeError.
 func (a *App) HandleError(err error, logError bool) {
 	if err != nil {
 		if logError {
@@ -148,6 +148,49 @@ func Test_App_PrintAndExit_PrintsToOutputStreamAndExits(t *testing.T) {
 	}
 }
 
+func Test_App_CancelAndExit_ExitsWithoutPrintingToOutputStream(t *testing.T) {
+	var out bytes.Buffer
+	screen := tcell.NewSimulationScreen("")
+	app := NewApp(screen, &out, io.Discard)
+	performedNormalExit, performedCancelExit := false, false
+	app.handleNormalExit = func() {
+		performedNormalExit = true
+	}
+	app.handleCancelExit = func() {
+		performedCancelExit = true
+	}
+
+	app.CancelAndExit()
+
+	if out.Len() > 0 {
+		t.Errorf("Expected no output, got '%s' instead", out.String())
+	}
+
+	if !performedCancelExit || performedNormalExit {
+		t.Error("Expected app to exit as cancelled but it didn't")
+	}
+}
+
+func Test_App_InterruptAndExit_ExitsWithoutPrintingToOutputStream(t *testing.T) {
+	var out bytes.Buffer
+	screen := tcell.NewSimulationScreen("")
+	app := NewApp(screen, &out, io.Discard)
+	performedInterrupt := false
+	app.handleInterrupt = func() {
+		performedInterrupt = true
+	}
+
+	app.InterruptAndExit()
+
+	if out.Len() > 0 {
+		t.Errorf("Expected no output, got '%s' instead", out.String())
+	}
+
+	if !performedInterrupt {
+		t.Error("Expected app to exit as interrupted but it didn't")
+	}
+}
+
 func Test_App_HandleError_DoesNothingIfNoError(t *testing.T) {
 	var out bytes.Buffer
 	log.SetOutput(&out)
@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+const maxBookmarkNameLength = 32
+
+// BookmarkForm provides the user interface that enables the user to name a bookmark of a
+// directory.
+type BookmarkForm struct {
+	*tview.Form
+	name        *tview.InputField
+	path        string
+	doneHandler func(key tcell.Key)
+}
+
+// CreateBookmarkForm creates a new instance of BookmarkForm and initializes its form fields.
+func CreateBookmarkForm() *BookmarkForm {
+	name := tview.NewInputField().
+		SetLabel("Bookmark name:").
+		SetFieldWidth(30)
+
+	form := tview.NewForm().
+		AddFormItem(name)
+
+	form.SetBorder(true).
+		SetTitle("Bookmark Directory").
+		SetBorderPadding(1, 1, 1, 1)
+
+	bookmarkForm := &BookmarkForm{
+		Form: form,
+		name: name,
+	}
+
+	name.SetAcceptanceFunc(bookmarkForm.handleNameAcceptance)
+	name.SetDoneFunc(bookmarkForm.handleNameDone)
+
+	return bookmarkForm
+}
+
+// handleNameAcceptance is a handler that determines which characters can be entered in the
+// name field. Bookmark names must not contain whitespace or path separators.
+func (b *BookmarkForm) handleNameAcceptance(textToCheck string, lastChar rune) bool {
+	if unicode.IsSpace(lastChar) || strings.ContainsRune(`/\`, lastChar) {
+		return false
+	}
+
+	return len(textToCheck) <= maxBookmarkNameLength
+}
+
+// handleNameDone is an event handler that triggers when entry of the name is completed or
+// cancelled.
+func (b *BookmarkForm) handleNameDone(key tcell.Key) {
+	if (key == tcell.KeyEnter || key == tcell.KeyEsc) && b.doneHandler != nil {
+		b.doneHandler(key)
+	}
+}
+
+// SetPath sets the directory that is bookmarked, and suggests its base name as the name of
+// the bookmark.
+func (b *BookmarkForm) SetPath(path string) *BookmarkForm {
+	b.path = path
+	b.name.SetText(strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return '-'
+		}
+		return r
+	}, filepath.Base(path)))
+	b.SetTitle("Bookmark " + tview.Escape(path))
+
+	return b
+}
+
+// GetPath returns the directory that is bookmarked.
+func (b *BookmarkForm) GetPath() string {
+	return b.path
+}
+
+// GetName returns the name that was entered for the bookmark.
+func (b *BookmarkForm) GetName() string {
+	return b.name.GetText()
+}
+
+// SetDoneHandler sets a key press event handler for external components to implement when input
+// is completed on the BookmarkForm.
+func (b *BookmarkForm) SetDoneHandler(handler func(key tcell.Key)) *BookmarkForm {
+	b.doneHandler = handler
+
+	return b
+}
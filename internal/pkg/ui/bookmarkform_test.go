@@ -0,0 +1,45 @@
+package ui
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"testing"
+)
+
+func Test_BookmarkForm_SetPath_SuggestsBaseNameOfDirectory(t *testing.T) {
+	form := CreateBookmarkForm().SetPath("/src/my project")
+
+	if form.GetName() != "my-project" {
+		t.Errorf("Expected the suggested name to be 'my-project', got '%s' instead", form.GetName())
+	}
+
+	if form.GetPath() != "/src/my project" {
+		t.Errorf("Expected the path to be '/src/my project', got '%s' instead", form.GetPath())
+	}
+}
+
+func Test_BookmarkForm_handleNameAcceptance_RejectsWhitespaceAndPathSeparators(t *testing.T) {
+	form := CreateBookmarkForm()
+
+	for _, char := range []rune{' ', '\t', '/', '\\'} {
+		if form.handleNameAcceptance("api"+string(char), char) {
+			t.Errorf("Did not expect the character '%c' to be accepted", char)
+		}
+	}
+
+	if !form.handleNameAcceptance("api-2", '2') {
+		t.Error("Expected the name 'api-2' to be accepted")
+	}
+}
+
+func Test_BookmarkForm_handleNameDone_CallsDoneHandler(t *testing.T) {
+	var result tcell.Key
+	form := CreateBookmarkForm().SetDoneHandler(func(key tcell.Key) {
+		result = key
+	})
+
+	form.handleNameDone(tcell.KeyEnter)
+
+	if result != tcell.KeyEnter {
+		t.Errorf("Expected the done handler to be called with Enter, got '%v' instead", result)
+	}
+}
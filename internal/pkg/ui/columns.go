@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"log"
+	"path/filepath"
+)
+
+// DirectoryColumn is a read-only list of directories that is displayed next to the
+// DirectoryList in the columns layout, where it shows the parent directory with the current
+// directory selected.
+type DirectoryColumn struct {
+	*tview.List
+}
+
+// CreateDirectoryColumn creates a new instance of DirectoryColumn.
+func CreateDirectoryColumn() *DirectoryColumn {
+	column := &DirectoryColumn{
+		List: tview.NewList().
+			ShowSecondaryText(false).
+			SetSelectedTextColor(tcell.ColorBlack),
+	}
+
+	column.SetBorder(true).
+		SetBorderPadding(1, 1, 0, 1).
+		SetDrawFunc(GetScrollBarDrawFunc(
+			column,
+			column.handleScrollArea,
+			column.handleScrollPosition))
+
+	return column
+}
+
+// Load displays the texts of the directories under the title and selects the directory at the
+// index.
+func (c *DirectoryColumn) Load(title string, texts []string, selected int) {
+	c.Clear()
+	c.SetTitle(title)
+
+	for _, text := range texts {
+		c.AddItem(text, "", 0, nil)
+	}
+	c.SetCurrentItem(selected)
+}
+
+// handleScrollArea calculates the width and height of the area that is scrollable in the
+// DirectoryColumn.
+func (c *DirectoryColumn) handleScrollArea() (width, height int) {
+	_, _, columnWidth, _ := c.GetInnerRect()
+
+	return columnWidth, c.GetItemCount()
+}
+
+// handleScrollPosition calculates the current scroll position of the DirectoryColumn.
+func (c *DirectoryColumn) handleScrollPosition() (vScroll, hScroll int) {
+	return c.GetOffset()
+}
+
+// SetParentColumn sets the column that displays the parent of the current directory in the
+// columns layout.
+func (d *DirectoryList) SetParentColumn(column *DirectoryColumn) *DirectoryList {
+	d.parentColumn = column
+
+	return d
+}
+
+// loadParentColumn displays the directories of the parent of the current directory in the
+// parent column, with the current directory selected. The directories are read, sorted and
+// displayed like those of the DirectoryList, but the filter only applies to the current
+// directory. The column is empty at the root.
+func (d *DirectoryList) loadParentColumn() {
+	parentDir := filepath.Dir(d.currentDir)
+	if parentDir == d.currentDir {
+		d.parentColumn.Load("", nil, 0)
+		return
+	}
+
+	var (
+		texts    []string
+		selected int
+	)
+
+	if _, err := d.scanVisibleItems(d.dirUtil.ScanDirectory, parentDir, func(dirName string) {
+		if dirName == filepath.Base(d.currentDir) {
+			selected = len(texts)
+		}
+		texts = append(texts, d.getDirectoryText(filepath.Join(parentDir, dirName), dirName, nil))
+	}); err != nil {
+		log.Print(err)
+	}
+
+	d.parentColumn.Load(getTreeRootText(parentDir), texts, selected)
+}
+
+// rememberColumnSelection records the selected item of the current directory, so that it is
+// selected again when the columns shift back to the directory.
+func (d *DirectoryList) rememberColumnSelection() {
+	if d.parentColumn == nil {
+		return
+	}
+
+	if d.columnSelections == nil {
+		d.columnSelections = make(map[string]string)
+	}
+	d.columnSelections[d.currentDir] = d.getItemName(d.GetCurrentItem())
+}
+
+// restoreColumnSelection selects the item with the name after the columns shift, and displays
+// its details. It returns false if the item is not listed.
+func (d *DirectoryList) restoreColumnSelection(name string) bool {
+	if d.parentColumn == nil || d.isMenuItem(name) || !d.selectItem(name) {
+		return false
+	}
+
+	d.setDetailsText(name)
+
+	return true
+}
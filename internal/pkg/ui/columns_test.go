@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"fmt"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/config"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/dirctrl"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func getColumnItemsForTest(column *DirectoryColumn) []string {
+	var items []string
+	for i := 0; i < column.GetItemCount(); i++ {
+		text, _ := column.GetItemText(i)
+		items = append(items, text)
+	}
+
+	return items
+}
+
+func Test_DirectoryList_load_ShowsParentDirectoryInParentColumn(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{
+		layout:           config.LayoutColumns,
+		startDir:         "src",
+		dirs:             []string{"docs", "src/api", "web"},
+		withParentColumn: true,
+	})
+	root := filepath.FromSlash(directoryListTestRoot)
+
+	if result := getColumnItemsForTest(list.parentColumn); fmt.Sprint(result) != fmt.Sprint([]string{"docs", "src", "web"}) {
+		t.Errorf("Expected the parent column to list [docs src web], got %v instead", result)
+	}
+	if selected, _ := list.parentColumn.GetItemText(list.parentColumn.GetCurrentItem()); selected != "src" {
+		t.Errorf("Expected the current directory to be selected in the parent column, got '%s' instead", selected)
+	}
+	if title := list.parentColumn.GetTitle(); title != filepath.Base(root) {
+		t.Errorf("Expected the parent column title to be '%s', got '%s' instead", filepath.Base(root), title)
+	}
+}
+
+func Test_DirectoryList_load_SortsAndIgnoresParentColumnLikeList(t *testing.T) {
+	appConfig := config.Default()
+	appConfig.Sort.Reverse = true
+	list, _ := getDirectoryListForTest(t, directoryListFixture{
+		config:           appConfig,
+		layout:           config.LayoutColumns,
+		startDir:         "src",
+		dirs:             []string{"build", "docs", "src/api", "web"},
+		onDisk:           true,
+		withParentColumn: true,
+	})
+	list.dirUtil.(*dirctrl.DefaultDirectoryController).Ignore = dirctrl.NewIgnoreRules([]string{"build"})
+	list.load()
+
+	if result := getColumnItemsForTest(list.parentColumn); fmt.Sprint(result) != fmt.Sprint([]string{"web", "src", "docs"}) {
+		t.Errorf("Expected the parent column to list [web src docs], got %v instead", result)
+	}
+
+	list.handleToggleIgnoredSelection()
+
+	expected := []string{"web", "src", "docs", "[gray]build[-]"}
+	if result := getColumnItemsForTest(list.parentColumn); fmt.Sprint(result) != fmt.Sprint(expected) {
+		t.Errorf("Expected the parent column to list %v, got %v instead", expected, result)
+	}
+	if selected, _ := list.parentColumn.GetItemText(list.parentColumn.GetCurrentItem()); selected != "src" {
+		t.Errorf("Expected the current directory to be selected in the parent column, got '%s' instead", selected)
+	}
+}
+
+func Test_DirectoryList_handleLeftKeyEvent_SelectsPreviousDirectoryInColumnsLayout(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{
+		layout:           config.LayoutColumns,
+		startDir:         "src/web",
+		dirs:             []string{"src/api", "src/web/static"},
+		withParentColumn: true,
+	})
+	root := filepath.FromSlash(directoryListTestRoot)
+
+	list.handleLeftKeyEvent()
+
+	if list.currentDir != filepath.Join(root, "src") {
+		t.Fatalf("Expected the current directory to be '%s', got '%s' instead", filepath.Join(root, "src"), list.currentDir)
+	}
+	if name := list.getItemName(list.GetCurrentItem()); name != "web" {
+		t.Errorf("Expected the previous directory 'web' to be selected, got '%s' instead", name)
+	}
+	if text := list.details.GetText(false); !strings.Contains(text, "static") {
+		t.Errorf("Expected the details pane to preview 'web', got the following instead:\n%s\n", text)
+	}
+}
+
+func Test_DirectoryList_handleRightKeyEvent_RestoresSelectionInColumnsLayout(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{
+		layout:           config.LayoutColumns,
+		startDir:         "src",
+		dirs:             []string{"src/api/cmd", "src/api/internal"},
+		withParentColumn: true,
+	})
+	list.selectItem("api")
+	list.handleRightKeyEvent()
+	list.selectItem("internal")
+
+	list.handleLeftKeyEvent()
+	list.handleRightKeyEvent()
+
+	if name := list.getItemName(list.GetCurrentItem()); name != "internal" {
+		t.Errorf("Expected the selection 'internal' to be restored, got '%s' instead", name)
+	}
+}
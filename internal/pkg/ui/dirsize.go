@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"fmt"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/dirctrl"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/utils"
+	"log"
+	"time"
+)
+
+const directorySizeCalculating = "calculating…"
+
+// directorySizeCacheDuration is how long a calculated directory size is reused before it is
+// calculated again, so that sizes do not go stale while directories are being cleaned up.
+const directorySizeCacheDuration = time.Minute
+
+// directorySizeJob is the calculation of the size of a directory that runs in the background.
+type directorySizeJob struct {
+	path string
+	stop chan struct{}
+}
+
+// cachedDirectorySize is the calculated size of a directory and the time it was calculated.
+type cachedDirectorySize struct {
+	size       dirctrl.DirectorySize
+	calculated time.Time
+}
+
+// getDirectorySizeText returns the line that displays the total size of the directory above
+// its directory info. Sizes that are not cached are calculated in the background, and the
+// line says calculating until the size is known.
+func (d *DirectoryList) getDirectorySizeText(directory string) string {
+	if !d.appConfig.Info.TotalSize {
+		return ""
+	}
+
+	if cached, exists := d.directorySizes[directory]; exists &&
+		time.Since(cached.calculated) < directorySizeCacheDuration {
+		return getDirectorySizeLine(formatDirectorySize(cached.size))
+	}
+
+	job := &directorySizeJob{path: directory, stop: make(chan struct{})}
+	d.sizeJob = job
+	go d.calculateDirectorySize(job)
+
+	return getDirectorySizeLine(directorySizeCalculating)
+}
+
+// cancelDirectorySize stops the running calculation of a directory size.
+func (d *DirectoryList) cancelDirectorySize() {
+	if d.sizeJob != nil {
+		close(d.sizeJob.stop)
+		d.sizeJob = nil
+	}
+}
+
+// calculateDirectorySize calculates the size of the directory of the job and displays it in
+// the event loop of the application, unless the job has been cancelled by then. It runs
+// outside the event loop, so it must not access the DirectoryList directly.
+func (d *DirectoryList) calculateDirectorySize(job *directorySizeJob) {
+	size, err := d.dirUtil.GetDirectorySize(job.path, job.stop)
+
+	select {
+	case <-job.stop:
+		return
+	default:
+	}
+
+	d.queueUpdateDraw(func() {
+		if d.sizeJob != job {
+			return
+		}
+		d.sizeJob = nil
+
+		text := "[red]unavailable[-]"
+		if err != nil {
+			log.Print(err)
+		} else {
+			if d.directorySizes == nil {
+				d.directorySizes = make(map[string]cachedDirectorySize)
+			}
+			d.directorySizes[job.path] = cachedDirectorySize{size, time.Now()}
+			text = formatDirectorySize(size)
+		}
+
+		d.replaceDetailsPlaceholder(getDirectorySizeLine(directorySizeCalculating), getDirectorySizeLine(text))
+	})
+}
+
+// getDirectorySizeLine returns the line of the details pane that displays the size.
+func getDirectorySizeLine(size string) string {
+	return fmt.Sprintf("[yellow]Total size:[-] %v\n\n", size)
+}
+
+// formatDirectorySize returns the human-readable size, and notes that it is incomplete if some
+// of the descendants of the directory are inaccessible.
+func formatDirectorySize(size dirctrl.DirectorySize) string {
+	text := utils.FormatSize(size.Bytes)
+	if size.Incomplete {
+		text += " [gray](some directories are inaccessible)[-]"
+	}
+
+	return text
+}
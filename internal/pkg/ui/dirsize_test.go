@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"github.com/goldenpathtechnologies/ci/internal/pkg/dirctrl"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func getDirectoryListForSizeTest(t *testing.T) (*DirectoryList, chan func(), string) {
+	list, updates := getDirectoryListForTest(t, directoryListFixture{
+		files:  map[string]string{"logs/app.log": strings.Repeat("\x00", 2048)},
+		onDisk: true,
+	})
+
+	return list, updates, filepath.Join(list.currentDir, "logs")
+}
+
+func waitForDetailsJobsForTest(t *testing.T, list *DirectoryList, updates chan func()) {
+	for list.sizeJob != nil || list.gitJob != nil || list.previewJob != nil || list.readmeJob != nil {
+		select {
+		case update := <-updates:
+			update()
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for the details of the directory")
+		}
+	}
+}
+
+func Test_DirectoryList_getDetailsText_DisplaysDirectorySizeWhenCalculated(t *testing.T) {
+	list, updates, logs := getDirectoryListForSizeTest(t)
+
+	list.details.SetText(list.getDetailsText(logs))
+
+	if text := list.details.GetText(true); !strings.HasPrefix(text, "Total size: calculating…") {
+		t.Errorf("Expected the size to be calculating, got the following instead:\n%s\n", text)
+	}
+
+	waitForDetailsJobsForTest(t, list, updates)
+
+	text := list.details.GetText(true)
+	if !strings.HasPrefix(text, "Total size: 2.0 KB") || !strings.Contains(text, "app.log") {
+		t.Errorf("Expected the size above the directory info, got the following instead:\n%s\n", text)
+	}
+}
+
+func Test_DirectoryList_getDetailsText_ReusesCachedDirectorySize(t *testing.T) {
+	list, updates, logs := getDirectoryListForSizeTest(t)
+
+	list.details.SetText(list.getDetailsText(logs))
+	waitForDetailsJobsForTest(t, list, updates)
+
+	if text := list.getDetailsText(logs); !strings.Contains(text, "2.0 KB") || list.sizeJob != nil {
+		t.Errorf("Expected the cached size without a new calculation, got the following instead:\n%s\n", text)
+	}
+}
+
+func Test_DirectoryList_setDetailsText_CancelsDirectorySizeOfPreviousSelection(t *testing.T) {
+	list, updates, logs := getDirectoryListForSizeTest(t)
+
+	list.details.SetText(list.getDetailsText(logs))
+	job := list.sizeJob
+	list.setDetailsText(listItemHelp)
+
+	select {
+	case <-job.stop:
+	default:
+		t.Fatal("Expected the calculation to be stopped")
+	}
+
+	select {
+	case update := <-updates:
+		update()
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	if text := list.details.GetText(true); strings.Contains(text, "Total size") {
+		t.Errorf("Expected the cancelled size to not be displayed, got the following instead:\n%s\n", text)
+	}
+	if _, exists := list.directorySizes[logs]; exists {
+		t.Error("Expected the size of a cancelled calculation to not be cached")
+	}
+}
+
+func Test_formatDirectorySize_NotesInaccessibleDescendants(t *testing.T) {
+	text := formatDirectorySize(dirctrl.DirectorySize{Bytes: 1536, Incomplete: true})
+
+	if !strings.HasPrefix(text, "1.5 KB") || !strings.Contains(text, "inaccessible") {
+		t.Errorf("Expected an incomplete size, got '%s' instead", text)
+	}
+}
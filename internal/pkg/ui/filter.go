@@ -1,8 +1,12 @@
 package ui
 
 import (
+	"errors"
 	"github.com/gdamore/tcell/v2"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/config"
 	"github.com/rivo/tview"
+	"regexp"
+	"regexp/syntax"
 	"strings"
 )
 
@@ -13,11 +17,14 @@ const (
 	filterMethodEndsWith
 	filterMethodContains
 	filterMethodGlobPattern
+	filterMethodFuzzy
+	filterMethodRegex
 )
 
 const (
 	filterTextField = iota
 	filterMethodField
+	filterIgnoreCaseField
 )
 
 // FilterForm provides the user interface that enables the user to filter the list of directories
@@ -26,9 +33,50 @@ type FilterForm struct {
 	*tview.Form
 	filterText   *tview.InputField
 	filterMethod *tview.DropDown
+	ignoreCase   *tview.Checkbox
+	message      *formMessage
 	doneHandler  func(key tcell.Key)
 }
 
+// formMessage is a read-only FormItem that displays a message below the fields of a Form. It
+// passes the focus on to the next item of the Form.
+type formMessage struct {
+	*tview.TextView
+	finishedHandler func(key tcell.Key)
+}
+
+// GetLabel returns the label of the formMessage, which is always empty.
+func (m *formMessage) GetLabel() string {
+	return ""
+}
+
+// SetFormAttributes sets the colors of the formMessage to those of the Form.
+func (m *formMessage) SetFormAttributes(labelWidth int, labelColor, bgColor, fieldTextColor, fieldBgColor tcell.Color) tview.FormItem {
+	m.SetBackgroundColor(bgColor)
+
+	return m
+}
+
+// GetFieldWidth returns the width of the formMessage, which takes all available space.
+func (m *formMessage) GetFieldWidth() int {
+	return 0
+}
+
+// SetFinishedFunc sets the handler that the Form uses to move the focus to its next item.
+func (m *formMessage) SetFinishedFunc(handler func(key tcell.Key)) tview.FormItem {
+	m.finishedHandler = handler
+
+	return m
+}
+
+// Focus passes the focus on to the next item of the Form, since the formMessage cannot be
+// edited.
+func (m *formMessage) Focus(delegate func(p tview.Primitive)) {
+	if m.finishedHandler != nil {
+		m.finishedHandler(tcell.KeyTab)
+	}
+}
+
 // CreateFilterForm creates a new instance of FilterForm and initializes its form fields.
 func CreateFilterForm() *FilterForm {
 	filterText := tview.NewInputField().
@@ -38,8 +86,8 @@ func CreateFilterForm() *FilterForm {
 	filterMethod := tview.NewDropDown().
 		SetLabel("Filter method:").
 		SetOptions(
-			[]string{"Begins with", "Ends with", "Contains", "Manual glob"},
-			func(text string, index int) {}).
+			[]string{"Begins with", "Ends with", "Contains", "Manual glob", "Fuzzy", "Regex"},
+			nil).
 		SetCurrentOption(filterMethodBeginsWith).
 		SetListStyles(
 			tcell.Style{}.
@@ -49,9 +97,18 @@ func CreateFilterForm() *FilterForm {
 				Foreground(tcell.ColorBlack).
 				Background(tcell.ColorWhite))
 
+	ignoreCase := tview.NewCheckbox().
+		SetLabel("Ignore case:")
+
+	message := &formMessage{
+		TextView: tview.NewTextView().SetDynamicColors(true),
+	}
+
 	form := tview.NewForm().
 		AddFormItem(filterText).
 		AddFormItem(filterMethod).
+		AddFormItem(ignoreCase).
+		AddFormItem(message).
 		SetFocus(filterTextField)
 
 	form.SetBorder(true).
@@ -62,9 +119,17 @@ func CreateFilterForm() *FilterForm {
 		Form:         form,
 		filterText:   filterText,
 		filterMethod: filterMethod,
+		ignoreCase:   ignoreCase,
+		message:      message,
 	}
 
 	filterText.SetAcceptanceFunc(filterForm.handleFilterAcceptance)
+	filterText.SetChangedFunc(func(text string) {
+		filterForm.validate()
+	})
+	filterMethod.SetSelectedFunc(func(text string, index int) {
+		filterForm.validate()
+	})
 	form.SetInputCapture(filterForm.handleFilterFormInput)
 
 	return filterForm
@@ -73,11 +138,15 @@ func CreateFilterForm() *FilterForm {
 // handleFilterAcceptance is a handler that determines which characters can be entered in
 // the filterText field.
 func (f *FilterForm) handleFilterAcceptance(textToCheck string, lastChar rune) bool {
+	filterMethod, _ := f.filterMethod.GetCurrentOption()
+	if filterMethod == filterMethodRegex {
+		return lastChar != '/' && len(textToCheck) <= maxFilterLength
+	}
+
 	if lastChar == '/' || lastChar == '\\' {
 		return false
 	}
 
-	filterMethod, _ := f.filterMethod.GetCurrentOption()
 	globChars := "*?[]!"
 	if filterMethod != filterMethodGlobPattern && strings.ContainsRune(globChars, lastChar) {
 		return false
@@ -94,6 +163,9 @@ func (f *FilterForm) handleFilterFormInput(event *tcell.EventKey) *tcell.EventKe
 		fallthrough
 	case tcell.KeyEnter:
 		if item, _ := f.GetFocusedItemIndex(); item == filterTextField {
+			if key == tcell.KeyEnter && f.validate() != nil {
+				return nil
+			}
 			f.doneHandler(key)
 			return nil
 		}
@@ -112,16 +184,22 @@ func (f *FilterForm) handleFilterFormInput(event *tcell.EventKey) *tcell.EventKe
 	return event
 }
 
-// GetText returns the text that is in the FilterForm's filterText field.
+// GetText returns the text that is in the FilterForm's filterText field. The text is converted
+// to a glob pattern unless the fuzzy filter method is selected.
 func (f *FilterForm) GetText() string {
-	filterText := f.filterText.GetText()
+	currentOptionIndex, _ := f.filterMethod.GetCurrentOption()
+
+	return formatFilterText(currentOptionIndex, f.filterText.GetText())
+}
+
+// formatFilterText converts the text that the user entered to the filter text of the
+// DirectoryList for the filter method.
+func formatFilterText(filterMethod int, filterText string) string {
 	if filterText == "" {
 		return filterText
 	}
 
-	currentOptionIndex, _ := f.filterMethod.GetCurrentOption()
-
-	switch currentOptionIndex {
+	switch filterMethod {
 	case filterMethodBeginsWith:
 		return filterText + "*"
 	case filterMethodEndsWith:
@@ -130,11 +208,70 @@ func (f *FilterForm) GetText() string {
 		return "*" + filterText + "*"
 	case filterMethodGlobPattern:
 		fallthrough
+	case filterMethodFuzzy:
+		fallthrough
+	case filterMethodRegex:
+		fallthrough
 	default:
 		return filterText
 	}
 }
 
+// compileFilterRegexp compiles the regular expression of the regex filter method.
+func compileFilterRegexp(filterText string, ignoreCase bool) (*regexp.Regexp, error) {
+	if ignoreCase {
+		filterText = "(?i)" + filterText
+	}
+
+	return regexp.Compile(filterText)
+}
+
+// validate displays an error message below the form fields when the filter text is not a
+// valid pattern for the selected filter method, and returns the error.
+func (f *FilterForm) validate() error {
+	var err error
+	if f.GetFilterMethod() == filterMethodRegex {
+		_, err = compileFilterRegexp(f.filterText.GetText(), false)
+	}
+
+	f.message.SetText(getFilterErrorText(err))
+
+	return err
+}
+
+// getFilterErrorText returns a short description of an invalid filter pattern that fits on a
+// single line, or an empty string if err is nil.
+func getFilterErrorText(err error) string {
+	var syntaxErr *syntax.Error
+	switch {
+	case err == nil:
+		return ""
+	case errors.As(err, &syntaxErr):
+		return "[red]" + tview.Escape(string(syntaxErr.Code)) + "[-]"
+	default:
+		return "[red]" + tview.Escape(err.Error()) + "[-]"
+	}
+}
+
+// GetFilterMethod returns the index of the selected filter method.
+func (f *FilterForm) GetFilterMethod() int {
+	currentOptionIndex, _ := f.filterMethod.GetCurrentOption()
+
+	return currentOptionIndex
+}
+
+// IsCaseInsensitive returns whether the filter ignores the case of directory names.
+func (f *FilterForm) IsCaseInsensitive() bool {
+	return f.ignoreCase.IsChecked()
+}
+
+// SetCaseInsensitive sets whether the filter ignores the case of directory names.
+func (f *FilterForm) SetCaseInsensitive(ignoreCase bool) *FilterForm {
+	f.ignoreCase.SetChecked(ignoreCase)
+
+	return f
+}
+
 // SetText sets the text in the FilterForm's filterText field.
 func (f *FilterForm) SetText(text string) *FilterForm {
 	f.filterText.SetText(text)
@@ -147,6 +284,18 @@ func (f *FilterForm) Clear() {
 	f.filterText.SetText("")
 }
 
+// SetFilterMethod selects the filter method with the specified configuration name. Unknown
+// names leave the current filter method unchanged.
+func (f *FilterForm) SetFilterMethod(method string) *FilterForm {
+	for i, name := range config.FilterMethods {
+		if name == method {
+			f.filterMethod.SetCurrentOption(i)
+		}
+	}
+
+	return f
+}
+
 // SetDoneHandler sets a key press event handler for external components to implement when input
 // is completed on the FilterForm.
 func (f *FilterForm) SetDoneHandler(handler func(key tcell.Key)) *FilterForm {
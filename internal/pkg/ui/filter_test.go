@@ -2,6 +2,7 @@ package ui
 
 import (
 	"github.com/gdamore/tcell/v2"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/config"
 	"github.com/rivo/tview"
 	"testing"
 )
@@ -251,3 +252,80 @@ func Test_FilterForm_handleFilterFormInput_RunsDoneHandlerWhenEscIsPressedAndFil
 		t.Error("Expected done function to be set, but it was not")
 	}
 }
+
+func Test_FilterForm_GetText_ReturnsUnmodifiedTextForFuzzyMethod(t *testing.T) {
+	filterForm := CreateFilterForm().SetFilterMethod(config.FilterMethodFuzzy)
+	filterForm.SetText("sfv2")
+
+	if result := filterForm.GetText(); result != "sfv2" {
+		t.Errorf("Expected the filter text to be 'sfv2', got '%s' instead", result)
+	}
+
+	if result := filterForm.GetFilterMethod(); result != filterMethodFuzzy {
+		t.Errorf("Expected the filter method to be %d, got %d instead", filterMethodFuzzy, result)
+	}
+}
+
+func Test_FilterForm_handleFilterAcceptance_AcceptsRegexCharactersInRegexMode(t *testing.T) {
+	filterForm := CreateFilterForm().SetFilterMethod(config.FilterMethodRegex)
+
+	for _, lastChar := range []rune{'*', '?', '[', ']', '(', ')', '|', '^', '$', '\\'} {
+		if !filterForm.handleFilterAcceptance("", lastChar) {
+			t.Errorf("Expected last char '%c' to be accepted", lastChar)
+		}
+	}
+
+	if filterForm.handleFilterAcceptance("", '/') {
+		t.Error("Expected last char '/' not to be accepted")
+	}
+}
+
+func Test_FilterForm_validate_DisplaysErrorForInvalidRegex(t *testing.T) {
+	filterForm := CreateFilterForm().SetFilterMethod(config.FilterMethodRegex)
+
+	filterForm.SetText("svc-(foo")
+
+	if err := filterForm.validate(); err == nil {
+		t.Error("Expected an error for an invalid regular expression")
+	}
+	if result := filterForm.message.GetText(true); result != "missing closing )" {
+		t.Errorf("Expected the message 'missing closing )', got '%s' instead", result)
+	}
+
+	filterForm.SetText("svc-(foo)")
+
+	if result := filterForm.message.GetText(true); result != "" {
+		t.Errorf("Expected the message to be cleared, got '%s' instead", result)
+	}
+}
+
+func Test_FilterForm_handleFilterFormInput_DoesNotRunDoneHandlerForInvalidRegex(t *testing.T) {
+	screen := tcell.NewSimulationScreen("")
+	app := tview.NewApplication().SetScreen(screen)
+	filterForm := CreateFilterForm().SetFilterMethod(config.FilterMethodRegex)
+	filterForm.SetText("[a-")
+	doneFuncCalled := false
+	filterForm.SetDoneHandler(func(key tcell.Key) {
+		doneFuncCalled = true
+	})
+
+	app.SetRoot(filterForm, false)
+
+	filterForm.handleFilterFormInput(tcell.NewEventKey(tcell.KeyEnter, rune(tcell.KeyEnter), tcell.ModNone))
+
+	if doneFuncCalled {
+		t.Error("Expected the done function not to be called for an invalid regular expression")
+	}
+}
+
+func Test_FilterForm_SetCaseInsensitive_ChecksIgnoreCaseCheckbox(t *testing.T) {
+	filterForm := CreateFilterForm()
+
+	if filterForm.IsCaseInsensitive() {
+		t.Error("Expected the filter to be case-sensitive by default")
+	}
+
+	if !filterForm.SetCaseInsensitive(true).IsCaseInsensitive() {
+		t.Error("Expected the filter to be case-insensitive")
+	}
+}
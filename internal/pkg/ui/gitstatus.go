@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/gitstatus"
+	"github.com/rivo/tview"
+	"log"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// gitStatusTimeout is how long git may take to report the status of a repository.
+const gitStatusTimeout = 2 * time.Second
+
+// gitStatusLoadingText is displayed in place of the Git status until it is read.
+const gitStatusLoadingText = "[yellow]Git:[-] loading…\n\n"
+
+// gitStatusJob reads the status of a Git repository in the background.
+type gitStatusJob struct {
+	cancel context.CancelFunc
+}
+
+// getGitStatusText returns the summary of the Git repository that contains the directory for
+// the details pane, or an empty string if the directory is not inside a work tree. The status
+// is read in the background, and a loading line is displayed until it is known.
+func (d *DirectoryList) getGitStatusText(directory string) string {
+	if !d.appConfig.Info.GitStatus {
+		return ""
+	}
+
+	if _, isRepository := gitstatus.FindRepository(directory); !isRepository {
+		return ""
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), gitStatusTimeout)
+	job := &gitStatusJob{cancel: cancel}
+	d.gitJob = job
+	go d.readGitStatus(ctx, job, directory)
+
+	return gitStatusLoadingText
+}
+
+// cancelGitStatus stops reading the status of a Git repository.
+func (d *DirectoryList) cancelGitStatus() {
+	if d.gitJob != nil {
+		d.gitJob.cancel()
+		d.gitJob = nil
+	}
+}
+
+// readGitStatus reads the status of the repository that contains the directory and displays it
+// in the event loop of the application, unless the job has been cancelled by then. It runs
+// outside the event loop, so it must not access the DirectoryList directly.
+func (d *DirectoryList) readGitStatus(ctx context.Context, job *gitStatusJob, directory string) {
+	status, err := gitstatus.Read(ctx, directory)
+	if ctx.Err() == context.Canceled {
+		return
+	}
+
+	d.queueUpdateDraw(func() {
+		if d.gitJob != job {
+			return
+		}
+		d.gitJob = nil
+		job.cancel()
+
+		d.replaceDetailsPlaceholder(gitStatusLoadingText, formatGitStatus(status, err))
+	})
+}
+
+// formatGitStatus returns the summary of the Git status for the details pane, or describes the
+// error that prevented it from being read.
+func formatGitStatus(status *gitstatus.Status, err error) string {
+	if err != nil {
+		message := "unavailable"
+		if err == gitstatus.ErrTimeout {
+			message = "git took too long to respond"
+		} else if errors.Is(err, exec.ErrNotFound) {
+			message = "git is not installed"
+		} else {
+			log.Print(err)
+		}
+
+		return fmt.Sprintf("[yellow]Git:[-] [red]%v[-]\n\n", message)
+	}
+
+	branch := "[red]detached HEAD[-]"
+	if status.Branch != "" {
+		branch = tview.Escape(status.Branch)
+	}
+	if status.Upstream != "" {
+		branch += " → " + tview.Escape(status.Upstream)
+		if status.Ahead == 0 && status.Behind == 0 {
+			branch += ", up to date"
+		}
+		if status.Ahead > 0 {
+			branch += fmt.Sprintf(", [green]%d ahead[-]", status.Ahead)
+		}
+		if status.Behind > 0 {
+			branch += fmt.Sprintf(", [red]%d behind[-]", status.Behind)
+		}
+	}
+
+	head := "no commits yet"
+	if status.Head != "" {
+		head = status.Head + " " + tview.Escape(status.Subject)
+	}
+
+	changes := "[green]clean[-]"
+	if !status.IsClean() {
+		var counts []string
+		for _, count := range []struct {
+			n    int
+			name string
+		}{
+			{status.Staged, "staged"},
+			{status.Modified, "modified"},
+			{status.Untracked, "untracked"},
+			{status.Conflicted, "[red]conflicted[-]"},
+		} {
+			if count.n > 0 {
+				counts = append(counts, fmt.Sprintf("%d %v", count.n, count.name))
+			}
+		}
+		changes = strings.Join(counts, ", ")
+	}
+
+	text := fmt.Sprintf("[yellow]Git:[-] %v\n[yellow]HEAD:[-] %v\n[yellow]Changes:[-] %v\n", branch, head, changes)
+	if status.Stashes > 0 {
+		text += fmt.Sprintf("[yellow]Stashes:[-] %d\n", status.Stashes)
+	}
+
+	return text + "\n"
+}
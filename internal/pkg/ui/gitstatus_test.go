@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"github.com/goldenpathtechnologies/ci/internal/pkg/gitstatus"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_DirectoryList_getDetailsText_DisplaysGitStatusOfRepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	list, updates, logs := getDirectoryListForSizeTest(t)
+	list.appConfig.Info.TotalSize = false
+	if output, err := exec.Command("git", "init", "-q", logs).CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v\n%s", err, output)
+	}
+
+	list.details.SetText(list.getDetailsText(logs))
+
+	if text := list.details.GetText(true); !strings.HasPrefix(text, "Git: loading…") {
+		t.Errorf("Expected the Git status to be loading, got the following instead:\n%s\n", text)
+	}
+
+	waitForDetailsJobsForTest(t, list, updates)
+
+	text := list.details.GetText(true)
+	if !strings.Contains(text, "HEAD: no commits yet\nChanges: 1 untracked\n") || !strings.Contains(text, "app.log") {
+		t.Errorf("Expected the Git status above the directory info, got the following instead:\n%s\n", text)
+	}
+}
+
+func Test_DirectoryList_getDetailsText_OmitsGitStatusOutsideRepository(t *testing.T) {
+	list, _, logs := getDirectoryListForSizeTest(t)
+	if _, isRepository := gitstatus.FindRepository(logs); isRepository {
+		t.Skip("The temporary directory is inside a Git repository")
+	}
+
+	if text := list.getDetailsText(logs); strings.Contains(text, "Git:") || list.gitJob != nil {
+		t.Errorf("Expected no Git status, got the following instead:\n%s\n", text)
+	}
+}
+
+func Test_DirectoryList_setDetailsText_CancelsGitStatusOfPreviousSelection(t *testing.T) {
+	list, _, logs := getDirectoryListForSizeTest(t)
+	if err := os.Mkdir(filepath.Join(logs, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	list.details.SetText(list.getDetailsText(logs))
+	if list.gitJob == nil {
+		t.Fatal("Expected the Git status to be read")
+	}
+
+	list.setDetailsText(listItemHelp)
+
+	if list.gitJob != nil {
+		t.Error("Expected the Git status of the previous selection to be cancelled")
+	}
+}
+
+func Test_formatGitStatus_SummarizesBranchAndChanges(t *testing.T) {
+	status := &gitstatus.Status{
+		Branch: "main", Upstream: "origin/main", Ahead: 2, Behind: 1,
+		Head: "1e4c9ba", Subject: "Fix [the] build", Staged: 1, Untracked: 3, Stashes: 2,
+	}
+
+	expected := "[yellow]Git:[-] main → origin/main, [green]2 ahead[-], [red]1 behind[-]\n" +
+		"[yellow]HEAD:[-] 1e4c9ba Fix [the[] build\n" +
+		"[yellow]Changes:[-] 1 staged, 3 untracked\n" +
+		"[yellow]Stashes:[-] 2\n\n"
+	if result := formatGitStatus(status, nil); result != expected {
+		t.Errorf("Expected the following summary:\n%s\nGot the following instead:\n%s\n", expected, result)
+	}
+}
+
+func Test_formatGitStatus_DescribesTimeout(t *testing.T) {
+	if result := formatGitStatus(nil, gitstatus.ErrTimeout); !strings.Contains(result, "too long") {
+		t.Errorf("Expected the timeout to be described, got '%s' instead", result)
+	}
+}
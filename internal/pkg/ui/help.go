@@ -3,6 +3,7 @@ package ui
 import (
 	"fmt"
 	"github.com/gdamore/tcell/v2"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/config"
 	"github.com/goldenpathtechnologies/ci/internal/pkg/options"
 	"time"
 )
@@ -24,6 +25,7 @@ func GetHelpText(options *options.AppOptions) string {
 	}
 
 	copyrightYear = buildDate.Year()
+	appConfig := options.GetConfig()
 
 	helpText := fmt.Sprintf(`[yellow]Directory List[white]
 [green]%c[white]        Navigate to child directory (when selected)
@@ -34,10 +36,35 @@ func GetHelpText(options *options.AppOptions) string {
 [green]%s[white]     Select last item on next page
 [green]%s[white]    Enter selected directory/Select option
 [green]%s[white]      Select the details pane
-[green]%s[white]        Exit and navigate to current directory
-[green]%s[white]        Open filter dialog
-[green]%s[white]        Show this help text
-[green]%s[white]        Exit without navigating
+[green]%-8s[white] Select the first directory that begins with the typed characters%s
+[green]%-8s[white] Exit and navigate to current directory
+[green]%-8s[white] Open filter dialog
+[green]%-8s[white] Filter as you type
+[green]%-8s[white] Search subdirectories as you type
+[green]%-8s[white] Switch between the list and the tree
+[green]%-8s[white] Type the path of a directory to go to
+[green]%-8s[white] Show/hide the README of directories in the details pane
+[green]%-8s[white] Show/hide hidden directories and files
+[green]%-8s[white] Show/hide ignored directories
+[green]%-8s[white] Sort by name, natural order, modification time, size or frecency
+[green]%-8s[white] Reverse the sort order
+[green]%-8s[white] Show recently visited directories
+[green]%-8s[white] Bookmark selected directory
+[green]%-8s[white] Show bookmarks
+[green]%-8s[white] Go back to previous directory (also Alt+%c)
+[green]%-8s[white] Go forward to next directory (also Alt+%c)
+[green]%-8s[white] Show directories visited in this session
+[green]%-8s[white] Show this help text
+[green]%-8s[white] Exit without navigating
+
+[yellow]Tree[white]
+[green]%c[white]        Expand selected directory, or select its first child
+[green]%c[white]        Collapse selected directory, or select its parent
+
+[yellow]Go to Directory[white]
+[green]%s[white]      Complete the directory name, or list the candidates
+[green]%s[white]    Go to the typed directory
+[green]%s[white]      Close without navigating
 
 [yellow]Details/Help[white]
 [green]%s[white]   Scroll text
@@ -47,6 +74,16 @@ func GetHelpText(options *options.AppOptions) string {
 [green]%s[white]
 [green]%s[white]
 
+[yellow]Recent Directories/Bookmarks/History[white]
+[green]%s[white]    Exit and navigate to selected directory
+[green]%c[white]        Browse selected directory in the directory list
+[green]%s[white]   Remove selected bookmark
+[green]%s[white]      Close the list
+
+[yellow]Live Filter/Search[white]
+[green]%s[white]     Remove the last character of the filter or search
+[green]%s[white]      Clear the filter or search and stop typing into it
+
 [yellow]Filter[white]
 [green]%s[white]    Enter filter text, or clear the existing filter if empty
 [green]%s[white]      Set focus to next input field
@@ -73,10 +110,34 @@ This program is MIT licensed.`,
 		"PgDn",
 		"ENTER",
 		"TAB",
-		"e",
-		"f",
-		"h",
-		"q",
+		"a-z",
+		getTypeAheadHelpNote(appConfig),
+		appConfig.GetKeyBinding(config.ActionEnterDirectory),
+		appConfig.GetKeyBinding(config.ActionFilter),
+		appConfig.GetKeyBinding(config.ActionLiveFilter),
+		appConfig.GetKeyBinding(config.ActionSearch),
+		appConfig.GetKeyBinding(config.ActionToggleTree),
+		appConfig.GetKeyBinding(config.ActionPathEntry),
+		appConfig.GetKeyBinding(config.ActionToggleReadme),
+		appConfig.GetKeyBinding(config.ActionToggleHidden),
+		appConfig.GetKeyBinding(config.ActionToggleIgnored),
+		appConfig.GetKeyBinding(config.ActionCycleSort),
+		appConfig.GetKeyBinding(config.ActionReverseSort),
+		appConfig.GetKeyBinding(config.ActionRecent),
+		appConfig.GetKeyBinding(config.ActionBookmark),
+		appConfig.GetKeyBinding(config.ActionBookmarks),
+		appConfig.GetKeyBinding(config.ActionBack),
+		tcell.RuneLArrow,
+		appConfig.GetKeyBinding(config.ActionForward),
+		tcell.RuneRArrow,
+		appConfig.GetKeyBinding(config.ActionHistory),
+		appConfig.GetKeyBinding(config.ActionHelp),
+		appConfig.GetKeyBinding(config.ActionQuit),
+		tcell.RuneRArrow,
+		tcell.RuneLArrow,
+		"TAB",
+		"ENTER",
+		"ESC",
 		"ARROWS",
 		"PgUp",
 		"PgDn",
@@ -84,6 +145,12 @@ This program is MIT licensed.`,
 		"TAB",
 		"ESC",
 		"ENTER",
+		tcell.RuneRArrow,
+		"DEL",
+		"ESC",
+		"BKSP",
+		"ESC",
+		"ENTER",
 		"TAB",
 		"SPACE",
 		"UP/DOWN",
@@ -98,4 +165,15 @@ This program is MIT licensed.`,
 	return helpText
 }
 
+// getTypeAheadHelpNote returns a note about the keys of the actions that are bound to single
+// characters when those keys are pressed with Alt so that they can be typed ahead.
+func getTypeAheadHelpNote(appConfig *config.Config) string {
+	if appConfig.TypeAhead.Timeout <= 0 {
+		return ""
+	}
+	if appConfig.TypeAhead.AltShortcuts {
+		return "\n         (press single character keys below with Alt)"
+	}
 
+	return "\n         (press letter keys below with Alt)"
+}
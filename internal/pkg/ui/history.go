@@ -0,0 +1,71 @@
+package ui
+
+// HistoryEntry is a directory that was visited during the session, together with the state of
+// the DirectoryList when the user left it.
+type HistoryEntry struct {
+	Directory    string
+	SelectedItem string
+	FilterText   string
+	FilterMethod int
+	IgnoreCase   bool
+}
+
+// NavigationHistory is a browser-style history of the directories visited during the session,
+// which can be navigated backwards and forwards.
+type NavigationHistory struct {
+	entries []HistoryEntry
+	current int
+}
+
+// NewNavigationHistory creates a new, empty instance of NavigationHistory.
+func NewNavigationHistory() *NavigationHistory {
+	return &NavigationHistory{current: -1}
+}
+
+// Visit adds the entry after the current entry and makes it the current entry. Entries that
+// could previously be reached by going forward are discarded.
+func (h *NavigationHistory) Visit(entry HistoryEntry) {
+	h.entries = append(h.entries[:h.current+1], entry)
+	h.current = len(h.entries) - 1
+}
+
+// Update replaces the current entry, which records the state of the DirectoryList before the
+// user navigates away from it.
+func (h *NavigationHistory) Update(entry HistoryEntry) {
+	if h.current >= 0 {
+		h.entries[h.current] = entry
+	}
+}
+
+// Back makes the previous entry the current entry and returns it. It returns false if there is
+// no previous entry.
+func (h *NavigationHistory) Back() (HistoryEntry, bool) {
+	return h.GoTo(h.current - 1)
+}
+
+// Forward makes the next entry the current entry and returns it. It returns false if there is
+// no next entry.
+func (h *NavigationHistory) Forward() (HistoryEntry, bool) {
+	return h.GoTo(h.current + 1)
+}
+
+// GoTo makes the entry at the index the current entry and returns it. It returns false if the
+// index is out of range.
+func (h *NavigationHistory) GoTo(index int) (HistoryEntry, bool) {
+	if index < 0 || index >= len(h.entries) {
+		return HistoryEntry{}, false
+	}
+
+	h.current = index
+	return h.entries[index], true
+}
+
+// Entries returns all entries from the oldest to the newest.
+func (h *NavigationHistory) Entries() []HistoryEntry {
+	return append([]HistoryEntry(nil), h.entries...)
+}
+
+// Current returns the index of the current entry, or -1 if there are no entries.
+func (h *NavigationHistory) Current() int {
+	return h.current
+}
@@ -0,0 +1,88 @@
+package ui
+
+import "testing"
+
+func getNavigationHistoryForTest(directories ...string) *NavigationHistory {
+	history := NewNavigationHistory()
+	for _, directory := range directories {
+		history.Visit(HistoryEntry{Directory: directory})
+	}
+
+	return history
+}
+
+func Test_NavigationHistory_Back_ReturnsPreviousEntry(t *testing.T) {
+	history := getNavigationHistoryForTest("/a", "/b", "/c")
+
+	entry, ok := history.Back()
+
+	if !ok || entry.Directory != "/b" {
+		t.Errorf("Expected to go back to '/b', got '%v' instead", entry)
+	}
+
+	if history.Current() != 1 {
+		t.Errorf("Expected the current index to be 1, got %d instead", history.Current())
+	}
+}
+
+func Test_NavigationHistory_Back_ReturnsFalseAtFirstEntry(t *testing.T) {
+	history := getNavigationHistoryForTest("/a")
+
+	if _, ok := history.Back(); ok {
+		t.Error("Did not expect to go back from the first entry")
+	}
+
+	if history.Current() != 0 {
+		t.Errorf("Expected the current index to remain 0, got %d instead", history.Current())
+	}
+}
+
+func Test_NavigationHistory_Forward_ReturnsNextEntry(t *testing.T) {
+	history := getNavigationHistoryForTest("/a", "/b")
+	history.Back()
+
+	entry, ok := history.Forward()
+
+	if !ok || entry.Directory != "/b" {
+		t.Errorf("Expected to go forward to '/b', got '%v' instead", entry)
+	}
+
+	if _, ok = history.Forward(); ok {
+		t.Error("Did not expect to go forward from the last entry")
+	}
+}
+
+func Test_NavigationHistory_Visit_DiscardsForwardEntries(t *testing.T) {
+	history := getNavigationHistoryForTest("/a", "/b", "/c")
+	history.Back()
+	history.Back()
+
+	history.Visit(HistoryEntry{Directory: "/d"})
+
+	entries := history.Entries()
+	if len(entries) != 2 || entries[1].Directory != "/d" {
+		t.Errorf("Expected the entries '/a' and '/d', got '%v' instead", entries)
+	}
+}
+
+func Test_NavigationHistory_Update_ReplacesCurrentEntry(t *testing.T) {
+	history := getNavigationHistoryForTest("/a", "/b")
+	history.Back()
+
+	history.Update(HistoryEntry{Directory: "/a", SelectedItem: "src", FilterText: "s*"})
+
+	entry, _ := history.GoTo(0)
+	if entry.SelectedItem != "src" || entry.FilterText != "s*" {
+		t.Errorf("Expected the entry to be updated, got '%v' instead", entry)
+	}
+}
+
+func Test_NavigationHistory_Update_DoesNothingWithoutEntries(t *testing.T) {
+	history := NewNavigationHistory()
+
+	history.Update(HistoryEntry{Directory: "/a"})
+
+	if len(history.Entries()) != 0 {
+		t.Errorf("Did not expect an entry to be added, got '%v'", history.Entries())
+	}
+}
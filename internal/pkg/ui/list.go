@@ -3,18 +3,28 @@ package ui
 import (
 	"fmt"
 	"github.com/gdamore/tcell/v2"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/bookmark"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/config"
 	"github.com/goldenpathtechnologies/ci/internal/pkg/dirctrl"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/frecency"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/fuzzy"
 	"github.com/goldenpathtechnologies/ci/internal/pkg/options"
 	"github.com/rivo/tview"
+	"log"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
+	"time"
 )
 
 const (
-	listItemQuit     = "<Quit>"
-	listItemHelp     = "<Help>"
-	listItemFilter   = "<Filter>"
-	listItemEnterDir = "<Enter directory>"
+	listItemQuit      = "<Quit>"
+	listItemHelp      = "<Help>"
+	listItemFilter    = "<Filter>"
+	listItemEnterDir  = "<Enter directory>"
+	listItemRecent    = "<Recent>"
+	listItemBookmarks = "<Bookmarks>"
 )
 
 const (
@@ -22,20 +32,87 @@ const (
 	detailsHelpTitle = "Help"
 )
 
+const (
+	listViewPage = "List"
+	treeViewPage = "Tree"
+)
+
+// RecentDirectoryStore records the directories that the user navigates to and ranks them by
+// frecency.
+type RecentDirectoryStore interface {
+	Add(directory string) error
+	Ranked() ([]frecency.Entry, error)
+}
+
+// BookmarkStore saves the bookmarks that the user creates.
+type BookmarkStore interface {
+	List() ([]bookmark.Bookmark, error)
+	Add(name, path string, overwrite bool) error
+	Remove(name string) error
+	IsStale(b bookmark.Bookmark) bool
+}
+
 // DirectoryList is responsible for providing the user interface that enables users to
 // quickly navigate directories and select other options.
 type DirectoryList struct {
 	*tview.List
-	app        *App
-	appOptions *options.AppOptions
-	pages      *tview.Pages
-	titleBox   *tview.TextView
-	filter     *FilterForm
-	details    *DetailsView
-	dirUtil    dirctrl.DirectoryController
-	currentDir string
-	filterText string
-	menuItems  map[string]string
+	app           *App
+	appOptions    *options.AppOptions
+	appConfig     *config.Config
+	pages         *tview.Pages
+	titleBox      *tview.TextView
+	filter        *FilterForm
+	details       *DetailsView
+	dirUtil       dirctrl.DirectoryController
+	currentDir    string
+	filterText    string
+	filterMethod  int
+	filterRegexp  *regexp.Regexp
+	filterErr     error
+	ignoreCase    bool
+	liveFilter    bool
+	liveQuery     string
+	typeAheadText string
+	typeAheadTime time.Time
+	searching     bool
+	search        *directorySearch
+	searchResults []searchResult
+	itemNames     map[string]string
+	fileNames     map[string]bool
+	showHidden    bool
+	hiddenCount   int
+	showIgnored   bool
+	sortMode      string
+	sortReverse   bool
+	menuItems     map[string]string
+	recent        *PathList
+	recentDirs    RecentDirectoryStore
+	bookmarks     *PathList
+	bookmarkForm  *BookmarkForm
+	bookmarkStore BookmarkStore
+	history       *NavigationHistory
+	historyList   *PathList
+	tree          *DirectoryTree
+	views         *tview.Pages
+	treeMode      bool
+	showReadme    bool
+	parentColumn  *DirectoryColumn
+	pathInput     *PathInput
+	header        *tview.Pages
+	// columnSelections maps directories to the item that was selected when the columns
+	// layout last shifted away from them.
+	columnSelections map[string]string
+	// directorySizes caches the total sizes of directories by their paths. sizeJob, gitJob and
+	// readmeJob complete the size, Git status and README of the directory that the details pane
+	// displays, and previewJob completes the preview of the file that it displays.
+	directorySizes map[string]cachedDirectorySize
+	sizeJob        *directorySizeJob
+	gitJob         *gitStatusJob
+	previewJob     *filePreviewJob
+	readmeJob      *readmeJob
+	// queueUpdateDraw runs a function in the event loop of the application and redraws the
+	// screen. Tests replace it because the event loop is not running.
+	queueUpdateDraw func(f func())
 }
 
 // CreateDirectoryList creates a new instance of DirectoryList.
@@ -53,22 +130,34 @@ func CreateDirectoryList(
 		SetSelectedTextColor(tcell.ColorBlack)
 
 	menuItems := map[string]string{
-		listItemQuit:     listItemQuit,
-		listItemHelp:     listItemHelp,
-		listItemFilter:   listItemFilter,
-		listItemEnterDir: listItemEnterDir,
+		listItemQuit:      listItemQuit,
+		listItemHelp:      listItemHelp,
+		listItemFilter:    listItemFilter,
+		listItemEnterDir:  listItemEnterDir,
+		listItemRecent:    listItemRecent,
+		listItemBookmarks: listItemBookmarks,
 	}
 
+	appConfig := appOptions.GetConfig()
+
 	return &DirectoryList{
-		List:       list,
-		app:        app,
-		appOptions: appOptions,
-		pages:      pages,
-		titleBox:   titleBox,
-		filter:     filter,
-		details:    details,
-		dirUtil:    directoryController,
-		menuItems:  menuItems,
+		List:        list,
+		app:         app,
+		appOptions:  appOptions,
+		appConfig:   appConfig,
+		pages:       pages,
+		titleBox:    titleBox,
+		filter:      filter,
+		details:     details,
+		dirUtil:     directoryController,
+		showHidden:  appConfig.ShowHidden,
+		sortMode:    appConfig.Sort.Mode,
+		sortReverse: appConfig.Sort.Reverse,
+		menuItems:   menuItems,
+		history:     NewNavigationHistory(),
+		queueUpdateDraw: func(f func()) {
+			app.QueueUpdateDraw(f)
+		},
 	}
 }
 
@@ -76,22 +165,123 @@ func CreateDirectoryList(
 func (d *DirectoryList) Init() *DirectoryList {
 	var err error
 
-	d.currentDir, err = d.dirUtil.GetInitialDirectory()
+	var startDir string
+	if d.appOptions != nil {
+		startDir = d.appOptions.StartDirectory
+	}
+
+	d.currentDir, err = d.dirUtil.GetInitialDirectory(startDir)
 	d.app.HandleError(err, true)
+	d.dirUtil.SetShowHidden(d.showHidden)
+	d.dirUtil.SetSortOrder(d.sortMode, d.sortReverse)
 
 	d.titleBox.Clear()
 	d.titleBox.SetText(d.currentDir)
+	d.history.Visit(HistoryEntry{Directory: d.currentDir})
 
 	d.loadDetailsForCurrentDirectory()
 	d.details.SetInputCapture(d.handleDetailsInputCapture)
 
 	d.filter.SetDoneHandler(d.handleFilterEntry)
 
+	if d.recent != nil {
+		d.recent.
+			SetSelectHandler(d.selectDirectory).
+			SetNavigateHandler(d.handlePathListNavigation).
+			SetDoneHandler(d.closePathLists)
+	}
+
+	if d.bookmarks != nil {
+		d.bookmarks.
+			SetSelectHandler(d.selectDirectory).
+			SetNavigateHandler(d.handlePathListNavigation).
+			SetDeleteHandler(d.handleBookmarkDeletion).
+			SetDoneHandler(d.closePathLists)
+	}
+
+	if d.bookmarkForm != nil {
+		d.bookmarkForm.SetDoneHandler(d.handleBookmarkEntry)
+	}
+
+	if d.historyList != nil {
+		d.historyList.
+			SetSelectHandler(d.handleHistoryEntrySelection).
+			SetNavigateHandler(d.handleHistoryEntrySelection).
+			SetDoneHandler(d.closePathLists)
+	}
+
+	if d.hasPathInput() {
+		d.pathInput.
+			SetCompleteHandler(d.completePath).
+			SetDoneHandler(d.handlePathEntry)
+	}
+
+	if d.hasTree() {
+		d.tree.
+			SetLoadHandler(d.getTreeEntries).
+			SetChangeHandler(d.setTreeDetailsText).
+			SetSelectHandler(d.selectDirectory).
+			SetInputCapture(d.handleTreeInputCapture)
+		d.treeMode = d.appConfig.Layout == config.LayoutTree
+	}
+
 	d.configureBorder().configureInputEvents().load()
 
+	if d.treeMode {
+		d.views.SwitchToPage(treeViewPage)
+	}
+
+	return d
+}
+
+// SetRecentDirectories sets the page that lists the recently visited directories and the store
+// that they are read from and recorded in. The <Recent> item is only shown when both are set.
+func (d *DirectoryList) SetRecentDirectories(recent *PathList, store RecentDirectoryStore) *DirectoryList {
+	d.recent = recent
+	d.recentDirs = store
+
 	return d
 }
 
+// SetBookmarks sets the page that lists the bookmarks, the form that names new bookmarks, and
+// the store that bookmarks are saved in. The <Bookmarks> item is only shown when all are set.
+func (d *DirectoryList) SetBookmarks(bookmarks *PathList, form *BookmarkForm, store BookmarkStore) *DirectoryList {
+	d.bookmarks = bookmarks
+	d.bookmarkForm = form
+	d.bookmarkStore = store
+
+	return d
+}
+
+// SetHistoryList sets the page that lists the directories visited during the session.
+func (d *DirectoryList) SetHistoryList(historyList *PathList) *DirectoryList {
+	d.historyList = historyList
+
+	return d
+}
+
+// SetTree sets the tree that can be displayed instead of the DirectoryList, and the pages
+// that switch between them. The pages must contain the DirectoryList and the tree.
+func (d *DirectoryList) SetTree(tree *DirectoryTree, views *tview.Pages) *DirectoryList {
+	d.tree = tree
+	d.views = views
+
+	return d
+}
+
+// SetPathInput sets the PathInput, and the pages that display it in place of the title box.
+func (d *DirectoryList) SetPathInput(pathInput *PathInput, header *tview.Pages) *DirectoryList {
+	d.pathInput = pathInput
+	d.header = header
+
+	return d
+}
+
+// hasBookmarks determines if the DirectoryList has everything it needs to manage bookmarks.
+func (d *DirectoryList) hasBookmarks() bool {
+	return d.bookmarks != nil && d.bookmarkForm != nil && d.bookmarkStore != nil
+}
+
 // loadDetailsForCurrentDirectory updates the details component with the file list for the
 // current active directory of the DirectoryList.
 func (d *DirectoryList) loadDetailsForCurrentDirectory() {
@@ -101,13 +291,17 @@ func (d *DirectoryList) loadDetailsForCurrentDirectory() {
 		ScrollToBeginning()
 }
 
-// getDetailsText returns the file list text that gets displayed in the Details pane.
+// getDetailsText returns the file list text that gets displayed in the Details pane, below the
+// status of its Git repository, the total size of the directory and its README. The jobs that
+// complete the details of the previously displayed directory are cancelled.
 func (d *DirectoryList) getDetailsText(directory string) string {
 	var (
 		detailsText string
 		err         error
 	)
 
+	d.cancelDetailsJobs()
+
 	if detailsText, err = d.dirUtil.GetDirectoryInfo(directory); err != nil {
 		dErr, isDirError := err.(*dirctrl.DirectoryError)
 		if isDirError && dErr.ErrorCode == dirctrl.DirUnprivilegedError {
@@ -115,24 +309,55 @@ func (d *DirectoryList) getDetailsText(directory string) string {
 		} else {
 			d.app.HandleError(err, true)
 		}
+
+		return detailsText
 	}
 
-	return detailsText
+	return d.getGitStatusText(directory) + d.getDirectorySizeText(directory) + d.getReadmeText(directory) + detailsText
+}
+
+// cancelDetailsJobs stops the background jobs that complete the details of the previously
+// displayed directory or file.
+func (d *DirectoryList) cancelDetailsJobs() {
+	d.cancelDirectorySize()
+	d.cancelGitStatus()
+	d.cancelFilePreview()
+	d.cancelReadme()
+}
+
+// replaceDetailsPlaceholder replaces the placeholder in the details pane with the text that a
+// background job completed, and keeps the scroll position of the details pane. Nothing is
+// replaced if the details pane no longer contains the placeholder.
+func (d *DirectoryList) replaceDetailsPlaceholder(placeholder, text string) {
+	detailsText := d.details.GetText(false)
+	if !strings.Contains(detailsText, placeholder) {
+		return
+	}
+
+	row, column := d.details.GetScrollOffset()
+	d.details.
+		SetText(strings.Replace(detailsText, placeholder, text, 1)).
+		ScrollTo(row, column)
 }
 
 // handleDetailsInputCapture is an event handler that processes key events for the details
 // component of the DirectoryList.
 func (d *DirectoryList) handleDetailsInputCapture(event *tcell.EventKey) *tcell.EventKey {
+	if d.appConfig.GetKeyBinding(config.ActionQuit).Matches(event) {
+		d.handleQuitSelection()
+		return nil
+	}
+
 	switch event.Key() {
 	case tcell.KeyEscape:
 		fallthrough
 	case tcell.KeyEnter:
 		fallthrough
 	case tcell.KeyTab:
-		d.app.SetFocus(d)
+		d.app.SetFocus(d.getView())
 		return nil
 	case 'q':
-		d.app.PrintAndExit(".")
+		d.handleQuitSelection()
 		return nil
 	}
 
@@ -146,20 +371,87 @@ func (d *DirectoryList) handleFilterEntry(key tcell.Key) {
 		d.filter.Clear()
 	}
 
-	d.filterText = d.filter.GetText()
-
-	if len(d.filterText) > 0 {
-		d.SetTitle(fmt.Sprintf("%v - Filter: %v", listTitle, d.filterText))
-	} else {
-		d.SetTitle(listTitle)
-	}
+	d.stopLiveFilter()
 
+	d.ignoreCase = d.filter.IsCaseInsensitive()
+	d.setFilter(d.filter.GetFilterMethod(), d.filter.GetText())
 	d.filter.Clear()
 	d.pages.HidePage("Filter")
-	d.app.SetFocus(d)
+	d.app.SetFocus(d.getView())
 	d.load()
 }
 
+// setFilter sets the filter of the DirectoryList and displays it in the title. The list must
+// be reloaded for the filter to take effect. The filter text is a glob pattern unless the
+// filter method is fuzzy or regex. Invalid regular expressions match no directories.
+func (d *DirectoryList) setFilter(filterMethod int, filterText string) {
+	d.filterMethod = filterMethod
+	d.filterText = filterText
+	d.filterRegexp, d.filterErr = nil, nil
+	if d.filterMethod == filterMethodRegex && len(d.filterText) > 0 {
+		d.filterRegexp, d.filterErr = compileFilterRegexp(d.filterText, d.ignoreCase)
+	}
+
+	d.updateTitle()
+}
+
+// updateTitle displays the filter of the DirectoryList and the number of hidden directories
+// in the title.
+func (d *DirectoryList) updateTitle() {
+	var (
+		title string
+		notes []string
+	)
+
+	switch {
+	case d.searching:
+		title = fmt.Sprintf("%v - Search: %v_", listTitle, tview.Escape(d.liveQuery))
+	case d.liveFilter:
+		title = fmt.Sprintf("%v - Live filter: %v_", listTitle, tview.Escape(d.liveQuery))
+	case len(d.filterText) == 0:
+		title = listTitle
+	case d.filterMethod == filterMethodFuzzy:
+		title = fmt.Sprintf("%v - Fuzzy filter: %v", listTitle, tview.Escape(d.filterText))
+	case d.filterMethod == filterMethodRegex:
+		title = fmt.Sprintf("%v - Regex filter: %v", listTitle, tview.Escape(d.filterText))
+	default:
+		title = fmt.Sprintf("%v - Filter: %v", listTitle, tview.Escape(d.filterText))
+	}
+
+	if d.searching && d.liveQuery != "" && d.filterErr == nil {
+		notes = append(notes, d.getSearchNote())
+	}
+
+	if d.filterErr != nil {
+		title += " " + getFilterErrorText(d.filterErr)
+	} else if d.ignoreCase && (d.liveFilter || len(d.filterText) > 0) {
+		notes = append(notes, "ignoring case")
+	}
+
+	if d.hiddenCount > 0 {
+		notes = append(notes, fmt.Sprintf("%d hidden", d.hiddenCount))
+	}
+
+	if d.showIgnored {
+		notes = append(notes, "showing ignored")
+	}
+
+	if d.sortReverse {
+		notes = append(notes, fmt.Sprintf("sort: %v reversed", d.sortMode))
+	} else if d.sortMode != config.SortModeName {
+		notes = append(notes, fmt.Sprintf("sort: %v", d.sortMode))
+	}
+
+	if len(notes) > 0 {
+		title += fmt.Sprintf(" (%v)", strings.Join(notes, ", "))
+	}
+
+	d.SetTitle(title)
+	if d.tree != nil {
+		d.tree.SetTitle(title)
+	}
+}
+
 // configureBorder applies default settings to the DirectoryList border and enables scroll bars.
 func (d *DirectoryList) configureBorder() *DirectoryList {
 	d.SetBorder(true).
@@ -212,6 +504,35 @@ func (d *DirectoryList) configureInputEvents() *DirectoryList {
 
 // handleInputCapture is an event handler that processes key events for the DirectoryList.
 func (d *DirectoryList) handleInputCapture(event *tcell.EventKey) *tcell.EventKey {
+	if d.liveFilter && d.handleLiveFilterInput(event) {
+		return nil
+	}
+
+	if d.handleTypeAhead(event) {
+		return nil
+	}
+
+	if handler := d.getKeyBindingHandler(event, true); handler != nil {
+		handler()
+		return nil
+	}
+
+	if handler := d.getAltShortcutHandler(event); handler != nil {
+		handler()
+		return nil
+	}
+
+	if event.Modifiers()&tcell.ModAlt != 0 {
+		switch event.Key() {
+		case tcell.KeyLeft:
+			d.handleBackSelection()
+			return nil
+		case tcell.KeyRight:
+			d.handleForwardSelection()
+			return nil
+		}
+	}
+
 	switch event.Key() {
 	case tcell.KeyLeft:
 		d.handleLeftKeyEvent()
@@ -233,64 +554,604 @@ func (d *DirectoryList) handleInputCapture(event *tcell.EventKey) *tcell.EventKe
 	return event
 }
 
+// listItemActions are the actions that have a list item, whose key bindings are also the
+// shortcuts of those list items.
+var listItemActions = map[string]bool{
+	config.ActionEnterDirectory: true,
+	config.ActionFilter:         true,
+	config.ActionHelp:           true,
+	config.ActionQuit:           true,
+	config.ActionRecent:         true,
+	config.ActionBookmarks:      true,
+}
+
+// handleToggleHiddenSelection shows or hides the hidden directories in the DirectoryList and
+// the hidden files in the details pane. The selected item remains selected if it is still
+// listed.
+func (d *DirectoryList) handleToggleHiddenSelection() {
+	selectedItem := d.getItemName(d.GetCurrentItem())
+
+	d.showHidden = !d.showHidden
+	d.dirUtil.SetShowHidden(d.showHidden)
+	d.reload(selectedItem)
+}
+
+// handleToggleIgnoredSelection temporarily shows the directories that match the ignore rules,
+// dimmed, or hides them again. The selected item remains selected if it is still listed.
+func (d *DirectoryList) handleToggleIgnoredSelection() {
+	selectedItem := d.getItemName(d.GetCurrentItem())
+
+	d.showIgnored = !d.showIgnored
+	d.dirUtil.SetShowIgnored(d.showIgnored)
+	d.reload(selectedItem)
+}
+
+// handleCycleSortSelection sorts the DirectoryList by the next sort mode.
+func (d *DirectoryList) handleCycleSortSelection() {
+	next := 0
+	for i, mode := range config.SortModes {
+		if mode == d.sortMode {
+			next = (i + 1) % len(config.SortModes)
+		}
+	}
+
+	d.setSortOrder(config.SortModes[next], d.sortReverse)
+}
+
+// handleReverseSortSelection reverses the order of the DirectoryList.
+func (d *DirectoryList) handleReverseSortSelection() {
+	d.setSortOrder(d.sortMode, !d.sortReverse)
+}
+
+// setSortOrder sorts the DirectoryList and the details pane by the sort mode and direction.
+// The selected item remains selected.
+func (d *DirectoryList) setSortOrder(mode string, reverse bool) {
+	selectedItem := d.getItemName(d.GetCurrentItem())
+
+	d.sortMode = mode
+	d.sortReverse = reverse
+	d.dirUtil.SetSortOrder(mode, reverse)
+	d.reload(selectedItem)
+}
+
+// reload loads the DirectoryList again and selects the item with the text, or the first item
+// if it is no longer listed.
+func (d *DirectoryList) reload(selectedItem string) {
+	d.load()
+
+	if d.selectItem(selectedItem) {
+		d.setDetailsText(selectedItem)
+	} else {
+		d.loadDetailsForCurrentDirectory()
+	}
+}
+
+// handleLiveFilterSelection starts the live filter, which narrows the DirectoryList with every
+// character that is typed.
+func (d *DirectoryList) handleLiveFilterSelection() {
+	d.liveFilter = true
+	d.liveQuery = ""
+	d.applyLiveFilter()
+}
+
+// stopLiveFilter ends the live filter or search without changing the filter of the
+// DirectoryList. The list must be reloaded for changes to the filter to take effect.
+func (d *DirectoryList) stopLiveFilter() {
+	d.liveFilter = false
+	d.liveQuery = ""
+	d.searching = false
+	d.cancelSearch()
+}
+
+// handleLiveFilterInput processes key events while the live filter is active. Characters are
+// added to the query and Backspace removes them, while Esc clears the filter and ends the live
+// filter. It returns false for other keys, such as the arrow keys, so that they keep working.
+func (d *DirectoryList) handleLiveFilterInput(event *tcell.EventKey) bool {
+	switch event.Key() {
+	case tcell.KeyRune:
+		if event.Modifiers()&(tcell.ModCtrl|tcell.ModAlt) != 0 {
+			return false
+		}
+		if query := d.liveQuery + string(event.Rune()); d.filter.handleFilterAcceptance(query, event.Rune()) {
+			d.liveQuery = query
+			d.applyLiveFilter()
+		}
+		return true
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if runes := []rune(d.liveQuery); len(runes) > 0 {
+			d.liveQuery = string(runes[:len(runes)-1])
+			d.applyLiveFilter()
+		}
+		return true
+	case tcell.KeyEscape:
+		d.stopLiveFilter()
+		d.setFilter(d.filterMethod, "")
+		d.load()
+		d.loadDetailsForCurrentDirectory()
+		return true
+	}
+
+	return false
+}
+
+// applyLiveFilter filters the DirectoryList by the live filter query with the filter method
+// that is selected in the filter dialog, and selects the first matching directory.
+func (d *DirectoryList) applyLiveFilter() {
+	if d.searching {
+		d.startSearch()
+		return
+	}
+
+	filterMethod := d.filter.GetFilterMethod()
+	d.ignoreCase = d.filter.IsCaseInsensitive()
+	d.setFilter(filterMethod, formatFilterText(filterMethod, d.liveQuery))
+	d.load()
+
+	if d.liveQuery != "" && d.GetItemCount() > 1 && !d.isMenuItem(d.getItemName(1)) {
+		d.SetCurrentItem(1)
+		d.setDetailsText(d.getItemName(1))
+	} else {
+		d.loadDetailsForCurrentDirectory()
+	}
+}
+
+// getKeyBindingHandler returns the handler of the action whose key binding matches the event.
+// When the list items are displayed, actions of list items that are bound to unmodified
+// characters are left to the shortcuts of the list items, so nil is returned for those.
+func (d *DirectoryList) getKeyBindingHandler(event *tcell.EventKey, hasListItems bool) func() {
+	handlers := map[string]func(){
+		config.ActionEnterDirectory: d.handleEnterDirectorySelection,
+		config.ActionFilter:         d.handleFilterSelection,
+		config.ActionHelp:           d.handleHelpSelection,
+		config.ActionQuit:           d.handleQuitSelection,
+		config.ActionRecent:         d.handleRecentSelection,
+		config.ActionBookmark:       d.handleBookmarkSelection,
+		config.ActionBookmarks:      d.handleBookmarksSelection,
+		config.ActionBack:           d.handleBackSelection,
+		config.ActionForward:        d.handleForwardSelection,
+		config.ActionHistory:        d.handleHistorySelection,
+		config.ActionLiveFilter:     d.handleLiveFilterSelection,
+		config.ActionToggleHidden:   d.handleToggleHiddenSelection,
+		config.ActionToggleIgnored:  d.handleToggleIgnoredSelection,
+		config.ActionCycleSort:      d.handleCycleSortSelection,
+		config.ActionReverseSort:    d.handleReverseSortSelection,
+		config.ActionSearch:         d.handleSearchSelection,
+		config.ActionToggleTree:     d.handleToggleTreeSelection,
+		config.ActionPathEntry:      d.handlePathEntrySelection,
+		config.ActionToggleReadme:   d.handleToggleReadmeSelection,
+	}
+
+	for action, handler := range handlers {
+		binding := d.appConfig.GetKeyBinding(action)
+		if (!hasListItems || binding.ShortcutRune() == 0 || !listItemActions[action]) && binding.Matches(event) {
+			return handler
+		}
+	}
+
+	return nil
+}
+
 // handleLeftKeyEvent handles left arrow key presses. The left arrow key navigates to the parent directory.
 func (d *DirectoryList) handleLeftKeyEvent() {
 	paths := strings.Split(strings.TrimRight(d.currentDir, dirctrl.OsPathSeparator), dirctrl.OsPathSeparator)
 	if len(paths) > 1 {
-		d.filterText = ""
-		d.SetTitle(listTitle)
+		var parentDir string
 		paths = paths[:len(paths)-1]
 		if len(paths) == 1 && (paths[0] == "" || strings.Contains(paths[0], ":")) {
-			d.currentDir, _ = d.dirUtil.GetAbsolutePath(dirctrl.OsPathSeparator)
+			parentDir, _ = d.dirUtil.GetAbsolutePath(dirctrl.OsPathSeparator)
 		} else {
-			d.currentDir = strings.Join(paths, dirctrl.OsPathSeparator)
+			parentDir = strings.Join(paths, dirctrl.OsPathSeparator)
 		}
-		d.load()
+		previousDir := d.currentDir
+		d.rememberColumnSelection()
+		d.changeDirectory(parentDir)
+		if !d.restoreColumnSelection(filepath.Base(previousDir)) {
+			d.loadDetailsForCurrentDirectory()
+		}
+	}
+}
+
+// changeDirectory navigates the DirectoryList to the directory and clears the filter. The
+// state of the DirectoryList in the previous directory is saved in the navigation history,
+// and the directory is added to it.
+func (d *DirectoryList) changeDirectory(directory string) {
+	d.saveHistoryState()
+	d.liveQuery = ""
+	d.typeAheadText = ""
+	d.cancelSearch()
+	d.setFilter(d.filterMethod, "")
+	d.currentDir = directory
+	d.load()
+	d.history.Visit(HistoryEntry{Directory: directory})
+}
+
+// saveHistoryState records the selected item and filter of the current directory in the
+// current entry of the navigation history.
+func (d *DirectoryList) saveHistoryState() {
+	d.history.Update(HistoryEntry{
+		Directory:    d.currentDir,
+		SelectedItem: d.getItemName(d.GetCurrentItem()),
+		FilterText:   d.filterText,
+		FilterMethod: d.filterMethod,
+		IgnoreCase:   d.ignoreCase,
+	})
+}
+
+// restoreHistoryEntry navigates the DirectoryList to the directory of the history entry, and
+// restores the filter and selected item that it had when the user left the directory.
+func (d *DirectoryList) restoreHistoryEntry(entry HistoryEntry) {
+	if !d.dirUtil.DirectoryIsAccessible(entry.Directory) {
+		d.details.Clear()
+		d.details.SetText("[red]Directory inaccessible, unable to navigate. You may have insufficient privileges.[white]").
+			ScrollToBeginning()
+		return
+	}
+
+	d.stopLiveFilter()
+	d.currentDir = entry.Directory
+	d.ignoreCase = entry.IgnoreCase
+	d.setFilter(entry.FilterMethod, entry.FilterText)
+	d.load()
+
+	if d.selectItem(entry.SelectedItem) {
+		d.setDetailsText(entry.SelectedItem)
+	} else {
 		d.loadDetailsForCurrentDirectory()
 	}
 }
 
+// selectItem selects the list item with the text. It returns false if there is no such item.
+func (d *DirectoryList) selectItem(text string) bool {
+	if text == "" {
+		return false
+	}
+
+	for i := 0; i < d.GetItemCount(); i++ {
+		if d.getItemName(i) == text {
+			d.SetCurrentItem(i)
+			return true
+		}
+	}
+
+	return false
+}
+
+// handleBackSelection navigates to the previous directory in the navigation history.
+func (d *DirectoryList) handleBackSelection() {
+	d.saveHistoryState()
+	if entry, ok := d.history.Back(); ok {
+		d.restoreHistoryEntry(entry)
+	}
+}
+
+// handleForwardSelection navigates to the next directory in the navigation history.
+func (d *DirectoryList) handleForwardSelection() {
+	d.saveHistoryState()
+	if entry, ok := d.history.Forward(); ok {
+		d.restoreHistoryEntry(entry)
+	}
+}
+
+// handleHistorySelection displays the list of directories visited during the session.
+func (d *DirectoryList) handleHistorySelection() {
+	if d.historyList == nil {
+		return
+	}
+
+	d.saveHistoryState()
+
+	var items []PathListItem
+	for i, entry := range d.history.Entries() {
+		item := PathListItem{Path: entry.Directory}
+		if i == d.history.Current() {
+			item.Label = "current"
+		}
+		items = append(items, item)
+	}
+
+	d.historyList.Load(items)
+	d.historyList.SetCurrentItem(d.history.Current())
+	d.pages.ShowPage("History")
+	d.app.SetFocus(d.historyList)
+}
+
+// handleHistoryEntrySelection navigates to the directory of the selected entry in the list of
+// directories visited during the session.
+func (d *DirectoryList) handleHistoryEntrySelection(string) {
+	index := d.historyList.GetCurrentItem()
+	d.closePathLists()
+
+	if entry, ok := d.history.GoTo(index); ok {
+		d.restoreHistoryEntry(entry)
+	}
+}
+
 // load refreshes static menu items and the list of navigable directories.
 func (d *DirectoryList) load() {
 	d.Clear()
 
-	d.AddItem(listItemEnterDir, "", 'e', func() {
-		d.app.PrintAndExit(d.currentDir)
-	})
+	d.AddItem(
+		listItemEnterDir,
+		"",
+		d.appConfig.GetKeyBinding(config.ActionEnterDirectory).ShortcutRune(),
+		d.handleEnterDirectorySelection)
 
-	if err := d.dirUtil.ScanDirectory(d.currentDir, func(dirName string) {
+	d.itemNames = make(map[string]string)
+	d.fileNames = make(map[string]bool)
+	d.hiddenCount = 0
+	if d.searching && len(d.filterText) > 0 {
+		d.loadSearchResults()
+	} else if d.filterMethod == filterMethodFuzzy && len(d.filterText) > 0 {
+		d.loadFuzzyMatches(d.dirUtil.ScanDirectory, d.addItemWithHighlights)
+	} else if err := d.dirUtil.ScanDirectory(d.currentDir, func(dirName string) {
 		d.addNavigableItem(dirName)
 	}); err != nil {
 		d.app.HandleError(err, true)
 	}
 
-	d.AddItem(listItemFilter, "Filter directories by text", 'f', func() {
-		d.pages.ShowPage("Filter")
-		d.app.SetFocus(d.filter)
-	})
+	if d.appConfig.ShowFiles && !(d.searching && len(d.filterText) > 0) {
+		d.loadFiles()
+	}
+
+	d.AddItem(
+		listItemFilter,
+		"Filter directories by text",
+		d.appConfig.GetKeyBinding(config.ActionFilter).ShortcutRune(),
+		d.handleFilterSelection)
+
+	if d.recent != nil && d.recentDirs != nil {
+		d.AddItem(
+			listItemRecent,
+			"Jump to a recently visited directory",
+			d.appConfig.GetKeyBinding(config.ActionRecent).ShortcutRune(),
+			d.handleRecentSelection)
+	}
+
+	if d.hasBookmarks() {
+		d.AddItem(
+			listItemBookmarks,
+			"Jump to a bookmarked directory",
+			d.appConfig.GetKeyBinding(config.ActionBookmarks).ShortcutRune(),
+			d.handleBookmarksSelection)
+	}
 
 	d.AddItem(
 		listItemHelp,
 		"Get help with this program",
-		'h',
+		d.appConfig.GetKeyBinding(config.ActionHelp).ShortcutRune(),
 		d.handleHelpSelection)
 
-	d.AddItem(listItemQuit, "Press to exit", 'q', func() {
-		d.app.PrintAndExit(".")
-	})
+	d.AddItem(
+		listItemQuit,
+		"Press to exit",
+		d.appConfig.GetKeyBinding(config.ActionQuit).ShortcutRune(),
+		d.handleQuitSelection)
 
 	d.titleBox.Clear()
 	d.titleBox.SetText(d.currentDir)
+	d.updateTitle()
+
+	if d.treeMode {
+		d.tree.Load(d.currentDir)
+	}
+
+	if d.parentColumn != nil {
+		d.loadParentColumn()
+	}
+}
+
+// loadFuzzyMatches adds the directories or files that the scan function finds and that match
+// the fuzzy filter to the DirectoryList with the addItem function, ordered from the best to
+// the worst match.
+func (d *DirectoryList) loadFuzzyMatches(
+	scan func(path string, callback func(name string)) error,
+	addItem func(name string, positions []int),
+) {
+	type fuzzyItem struct {
+		dirName string
+		match   fuzzy.Match
+	}
+
+	filter := d.getDirectoryFilter()
+	var items []fuzzyItem
+	hiddenCount, err := d.scanVisibleItems(scan, d.currentDir, func(dirName string) {
+		if match, isMatch := filter.match(dirName); isMatch {
+			items = append(items, fuzzyItem{dirName, match})
+		}
+	})
+	d.hiddenCount += hiddenCount
+	if err != nil {
+		d.app.HandleError(err, true)
+	}
+
+	// Note: Like fzf, shorter names are listed first when matches have the same score.
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].match.Score == items[j].match.Score {
+			return len(items[i].dirName) < len(items[j].dirName)
+		}
+		return items[i].match.Score > items[j].match.Score
+	})
+
+	for _, item := range items {
+		addItem(item.dirName, item.match.Positions)
+	}
+}
+
+// scanVisibleItems calls the callback with the name of each item in the path that the scan
+// function finds, unless it is hidden and hidden items are not shown. The scan functions of
+// the directory controller read items in the sort order, and skip ignored items unless they
+// are shown. It returns the number of hidden items that were skipped.
+func (d *DirectoryList) scanVisibleItems(
+	scan func(path string, callback func(name string)) error,
+	path string,
+	callback func(name string),
+) (int, error) {
+	hiddenCount := 0
+	err := scan(path, func(name string) {
+		if !d.showHidden && dirctrl.IsHidden(name) {
+			hiddenCount++
+			return
+		}
+		callback(name)
+	})
+
+	return hiddenCount, err
 }
 
 // addNavigableItem adds to the DirectoryList an item that contains a directory name and selection handler.
 func (d *DirectoryList) addNavigableItem(dirName string) {
-	if isMatch, _ := filepath.Match(d.filterText, dirName); len(d.filterText) == 0 || isMatch {
-		d.AddItem(dirName,
-			"",
-			0,
-			d.getNavigableItemSelectionHandler(dirName))
+	if !d.showHidden && dirctrl.IsHidden(dirName) {
+		d.hiddenCount++
+		return
+	}
+
+	if match, isMatch := d.getDirectoryFilter().match(dirName); isMatch {
+		d.addItemWithHighlights(dirName, match.Positions)
+	}
+}
+
+// directoryFilter is a copy of the filter of the DirectoryList that matches directory names.
+// Unlike the DirectoryList, it is safe to use outside the event loop of the application.
+type directoryFilter struct {
+	method     int
+	text       string
+	ignoreCase bool
+	regexp     *regexp.Regexp
+}
+
+// getDirectoryFilter returns a copy of the filter of the DirectoryList.
+func (d *DirectoryList) getDirectoryFilter() directoryFilter {
+	return directoryFilter{
+		method:     d.filterMethod,
+		text:       d.filterText,
+		ignoreCase: d.ignoreCase,
+		regexp:     d.filterRegexp,
+	}
+}
+
+// match determines if the directory name matches the filter. Every name matches an empty
+// filter. The match holds the score of fuzzy matches, and the positions of the characters to
+// highlight for fuzzy and regex matches. Invalid regular expressions match no names.
+func (f directoryFilter) match(dirName string) (fuzzy.Match, bool) {
+	switch {
+	case len(f.text) == 0:
+		return fuzzy.Match{}, true
+	case f.method == filterMethodFuzzy:
+		// Note: Fuzzy matching is only case-sensitive for uppercase patterns, so a lowercase
+		//  pattern ignores case.
+		pattern := f.text
+		if f.ignoreCase {
+			pattern = strings.ToLower(pattern)
+		}
+		return fuzzy.MatchText(pattern, dirName)
+	case f.method == filterMethodRegex:
+		if f.regexp == nil {
+			return fuzzy.Match{}, false
+		}
+		if loc := f.regexp.FindStringIndex(dirName); loc != nil {
+			return fuzzy.Match{Positions: getRunePositions(dirName, loc[0], loc[1])}, true
+		}
+		return fuzzy.Match{}, false
+	default:
+		pattern := f.text
+		if f.ignoreCase {
+			pattern, dirName = strings.ToLower(pattern), strings.ToLower(dirName)
+		}
+		isMatch, _ := filepath.Match(pattern, dirName)
+		return fuzzy.Match{}, isMatch
+	}
+}
+
+// getRunePositions returns the positions of the runes of the text that lie between the start
+// and end byte offsets.
+func getRunePositions(text string, start, end int) []int {
+	var positions []int
+	index := 0
+	for offset := range text {
+		if offset >= start && offset < end {
+			positions = append(positions, index)
+		}
+		index++
+	}
+
+	return positions
+}
+
+// addItemWithHighlights adds a navigable item for the directory to the DirectoryList, and
+// highlights the characters of its name at the positions. Ignored directories are dimmed. The
+// name of the directory is remembered when the displayed text differs from it.
+func (d *DirectoryList) addItemWithHighlights(dirName string, positions []int) {
+	text := d.getDirectoryText(d.currentDir+dirctrl.OsPathSeparator+dirName, dirName, positions)
+
+	if text != dirName {
+		if d.itemNames == nil {
+			d.itemNames = make(map[string]string)
+		}
+		d.itemNames[text] = dirName
+	}
+
+	d.AddItem(text,
+		"",
+		0,
+		d.getNavigableItemSelectionHandler(dirName))
+}
+
+// getDirectoryText returns the displayed text of the directory at the path, whose characters
+// at the positions of its name are highlighted. Ignored directories are dimmed.
+func (d *DirectoryList) getDirectoryText(path, dirName string, positions []int) string {
+	text := highlightRunes(dirName, positions)
+	if d.showIgnored && d.dirUtil.IsIgnored(path) {
+		text = "[gray]" + text + "[-]"
+	}
+
+	return text
+}
+
+// getItemName returns the directory name or menu item text of the list item at the index,
+// without the highlights of its displayed text.
+func (d *DirectoryList) getItemName(index int) string {
+	text, _ := d.GetItemText(index)
+	if name, exists := d.itemNames[text]; exists {
+		return name
+	}
+
+	return text
+}
+
+// highlightRunes escapes the text for display in a tview component, and underlines and
+// emboldens the runes at the positions.
+func highlightRunes(text string, positions []int) string {
+	if len(positions) == 0 {
+		return tview.Escape(text)
+	}
+
+	highlighted := make(map[int]bool)
+	for _, position := range positions {
+		highlighted[position] = true
+	}
+
+	var (
+		builder strings.Builder
+		segment []rune
+	)
+
+	// Note: Runs of runes are escaped together, because escaping runes individually would
+	//  not prevent them from forming color tags when they are joined.
+	runes := []rune(text)
+	for i, r := range runes {
+		segment = append(segment, r)
+
+		if i == len(runes)-1 || highlighted[i] != highlighted[i+1] {
+			if highlighted[i] {
+				builder.WriteString("[::bu]" + tview.Escape(string(segment)) + "[::-]")
+			} else {
+				builder.WriteString(tview.Escape(string(segment)))
+			}
+			segment = segment[:0]
+		}
 	}
+
+	return builder.String()
 }
 
 // getNavigableItemSelectionHandler handles the navigable item event by printing the path to the dirName
@@ -298,8 +1159,177 @@ func (d *DirectoryList) addNavigableItem(dirName string) {
 func (d *DirectoryList) getNavigableItemSelectionHandler(dirName string) func() {
 	return func() {
 		path := d.currentDir + dirctrl.OsPathSeparator + dirName
-		d.app.PrintAndExit(path)
+		d.selectDirectory(path)
+	}
+}
+
+// handleEnterDirectorySelection prints the path of the current directory and exits the program.
+func (d *DirectoryList) handleEnterDirectorySelection() {
+	d.selectDirectory(d.currentDir)
+}
+
+// selectDirectory records a visit to the directory, then prints its path and exits the program.
+// Failing to record the visit is logged but does not prevent the navigation.
+func (d *DirectoryList) selectDirectory(path string) {
+	if d.recentDirs != nil {
+		if err := d.recentDirs.Add(path); err != nil {
+			log.Print(err)
+		}
+	}
+
+	d.app.PrintAndExit(path)
+}
+
+// handleRecentSelection displays the list of recently visited directories.
+func (d *DirectoryList) handleRecentSelection() {
+	if d.recent == nil || d.recentDirs == nil {
+		return
+	}
+
+	entries, err := d.recentDirs.Ranked()
+	if err != nil {
+		log.Print(err)
+		d.details.Clear()
+		d.details.SetText("[red]Unable to read the recently visited directories.[white]").
+			ScrollToBeginning()
+		return
+	}
+
+	var items []PathListItem
+	for _, entry := range entries {
+		items = append(items, PathListItem{Path: entry.Path})
+	}
+
+	d.recent.Load(items)
+	d.pages.ShowPage("Recent")
+	d.app.SetFocus(d.recent)
+}
+
+// handleBookmarkSelection displays the dialog that names a bookmark of the selected directory,
+// or of the current directory if a menu item is selected.
+func (d *DirectoryList) handleBookmarkSelection() {
+	if !d.hasBookmarks() {
+		return
 	}
+
+	path := d.currentDir
+	if d.treeMode {
+		path = d.tree.GetSelectedPath()
+	} else if selectedItem := d.getItemName(d.GetCurrentItem()); d.GetItemCount() > 0 && !d.isMenuItem(selectedItem) && !d.fileNames[selectedItem] {
+		path = filepath.Join(d.currentDir, selectedItem)
+	}
+
+	d.bookmarkForm.SetPath(path)
+	d.pages.ShowPage("Bookmark")
+	d.app.SetFocus(d.bookmarkForm)
+}
+
+// handleBookmarkEntry is an event handler for the bookmark dialog that triggers when the name
+// of the bookmark is entered or cancelled. The outcome is displayed in the details component.
+func (d *DirectoryList) handleBookmarkEntry(key tcell.Key) {
+	d.pages.HidePage("Bookmark")
+	d.app.SetFocus(d.getView())
+
+	if key != tcell.KeyEnter {
+		return
+	}
+
+	name := d.bookmarkForm.GetName()
+	path := d.bookmarkForm.GetPath()
+
+	d.details.Clear()
+	if err := d.bookmarkStore.Add(name, path, false); err != nil {
+		d.details.SetText(fmt.Sprintf("[red]Unable to add the bookmark: %s[white]", tview.Escape(err.Error())))
+	} else {
+		d.details.SetText(fmt.Sprintf("[green]Bookmarked '%s' as %s[white]", tview.Escape(path), tview.Escape(name)))
+	}
+	d.details.ScrollToBeginning()
+}
+
+// handleBookmarksSelection displays the list of bookmarks.
+func (d *DirectoryList) handleBookmarksSelection() {
+	if !d.hasBookmarks() {
+		return
+	}
+
+	if err := d.loadBookmarks(); err != nil {
+		log.Print(err)
+		d.details.Clear()
+		d.details.SetText("[red]Unable to read the bookmarks.[white]").
+			ScrollToBeginning()
+		return
+	}
+
+	d.pages.ShowPage("Bookmarks")
+	d.app.SetFocus(d.bookmarks)
+}
+
+// loadBookmarks refreshes the list of bookmarks from the bookmark store.
+func (d *DirectoryList) loadBookmarks() error {
+	bookmarks, err := d.bookmarkStore.List()
+	if err != nil {
+		return err
+	}
+
+	var items []PathListItem
+	for _, b := range bookmarks {
+		items = append(items, PathListItem{
+			Label: b.Name,
+			Path:  b.Path,
+			Stale: d.bookmarkStore.IsStale(b),
+		})
+	}
+
+	d.bookmarks.Load(items)
+	return nil
+}
+
+// handleBookmarkDeletion removes the bookmark of the item from the list of bookmarks.
+func (d *DirectoryList) handleBookmarkDeletion(item PathListItem) {
+	if err := d.bookmarkStore.Remove(item.Label); err != nil {
+		log.Print(err)
+	}
+
+	currentItem := d.bookmarks.GetCurrentItem()
+	if err := d.loadBookmarks(); err != nil {
+		log.Print(err)
+	}
+	d.bookmarks.SetCurrentItem(currentItem)
+}
+
+// handlePathListNavigation closes the list of recently visited directories or bookmarks and
+// navigates the DirectoryList to the directory, without exiting the program.
+func (d *DirectoryList) handlePathListNavigation(path string) {
+	d.closePathLists()
+
+	if !d.dirUtil.DirectoryIsAccessible(path) {
+		d.details.Clear()
+		d.details.SetText("[red]Directory inaccessible, unable to navigate. You may have insufficient privileges.[white]").
+			ScrollToBeginning()
+		return
+	}
+
+	d.changeDirectory(path)
+	d.loadDetailsForCurrentDirectory()
+}
+
+// closePathLists hides the lists of recently visited directories and bookmarks.
+func (d *DirectoryList) closePathLists() {
+	d.pages.HidePage("Recent")
+	d.pages.HidePage("Bookmarks")
+	d.pages.HidePage("History")
+	d.app.SetFocus(d.getView())
+}
+
+// handleFilterSelection displays the filter dialog.
+func (d *DirectoryList) handleFilterSelection() {
+	d.pages.ShowPage("Filter")
+	d.app.SetFocus(d.filter)
+}
+
+// handleQuitSelection exits the program without navigating.
+func (d *DirectoryList) handleQuitSelection() {
+	d.app.CancelAndExit()
 }
 
 // handleHelpSelection handles the display of help information in the details component when the help
@@ -311,11 +1341,9 @@ func (d *DirectoryList) handleHelpSelection() {
 // handleRightKeyEvent handles right arrow key presses. The right arrow key navigates to the selected
 // directory or indicates if the navigation is not possible due to insufficient privileges.
 func (d *DirectoryList) handleRightKeyEvent() {
-	selectedItem, _ := d.GetItemText(d.GetCurrentItem())
+	selectedItem := d.getItemName(d.GetCurrentItem())
 
-	if !d.isMenuItem(selectedItem) {
-		d.filterText = ""
-		d.SetTitle(listTitle)
+	if !d.isMenuItem(selectedItem) && !d.fileNames[selectedItem] {
 		pathCount := len(strings.Split(strings.TrimRight(d.currentDir, dirctrl.OsPathSeparator), dirctrl.OsPathSeparator))
 		var pathSeparator string
 		if pathCount > 1 {
@@ -325,8 +1353,9 @@ func (d *DirectoryList) handleRightKeyEvent() {
 		}
 		nextDir := d.currentDir + pathSeparator + selectedItem
 		if d.dirUtil.DirectoryIsAccessible(nextDir) {
-			d.currentDir = nextDir
-			d.load()
+			d.rememberColumnSelection()
+			d.changeDirectory(nextDir)
+			d.restoreColumnSelection(d.columnSelections[nextDir])
 		} else {
 			d.details.Clear()
 			d.details.SetText("[red]Directory inaccessible, unable to navigate. You may have insufficient privileges.[white]").
@@ -344,7 +1373,7 @@ func (d *DirectoryList) isMenuItem(text string) bool {
 // setPreviousDetailsText sets the content of the details component to the directory info of
 // the previous item in the DirectoryList.
 func (d *DirectoryList) setPreviousDetailsText() {
-	item, _ := d.List.GetItemText(d.getNextItemIndex(false))
+	item := d.getItemName(d.getNextItemIndex(false))
 	d.setDetailsText(item)
 }
 
@@ -364,11 +1393,16 @@ func (d *DirectoryList) getNextItemIndex(isIncrementing bool) int {
 }
 
 // setDetailsText sets the content of the details component depending on the dirName supplied.
-// Items representing a directory will display the list of files in that directory. Menu items
-// display different content depending on which one is provided to this function.
+// Items representing a directory will display the list of files in that directory, and items
+// representing a file display a preview of the file. Menu items display different content
+// depending on which one is provided to this function.
 func (d *DirectoryList) setDetailsText(dirName string) {
+	d.cancelDetailsJobs()
 	d.details.Clear()
-	if !d.isMenuItem(dirName) {
+	if d.fileNames[dirName] {
+		d.details.SetText(d.getFilePreviewText(filepath.Join(d.currentDir, dirName)))
+		d.details.SetTitle(filePreviewTitle)
+	} else if !d.isMenuItem(dirName) {
 		d.details.SetText(d.getDetailsText(d.currentDir + dirctrl.OsPathSeparator + dirName))
 	} else if dirName == listItemEnterDir {
 		d.details.SetText(d.getDetailsText(d.currentDir))
@@ -382,6 +1416,6 @@ func (d *DirectoryList) setDetailsText(dirName string) {
 // setNextDetailsText sets the content of the details component to the directory info of
 // the next item in the DirectoryList.
 func (d *DirectoryList) setNextDetailsText() {
-	item, _ := d.List.GetItemText(d.getNextItemIndex(true))
+	item := d.getItemName(d.getNextItemIndex(true))
 	d.setDetailsText(item)
 }
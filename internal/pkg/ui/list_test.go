@@ -5,7 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"github.com/gdamore/tcell/v2"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/bookmark"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/config"
 	"github.com/goldenpathtechnologies/ci/internal/pkg/dirctrl"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/frecency"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/options"
 	"github.com/goldenpathtechnologies/ci/testdata/mock"
 	"github.com/google/uuid"
 	"github.com/rivo/tview"
@@ -13,6 +17,7 @@ import (
 	"io/fs"
 	"log"
 	"os"
+	"path"
 	"path/filepath"
 	"runtime"
 	"strconv"
@@ -38,6 +43,7 @@ func Test_DirectoryList_getDetailsText_ReturnsDirectoryDetails(t *testing.T) {
 	}
 
 	list := CreateDirectoryList(nil, nil, nil, nil, nil, dirCtrl, nil)
+	list.queueUpdateDraw = func(func()) {}
 
 	detailsText := list.getDetailsText(".")
 
@@ -173,10 +179,164 @@ func getAppWithDisabledExitHandlersAndOutputStreams(screen tcell.SimulationScree
 	app.handleErrorExit = func() {
 		// Do nothing for test
 	}
+	app.handleCancelExit = func() {
+		// Do nothing for test
+	}
+	app.handleInterrupt = func() {
+		// Do nothing for test
+	}
 
 	return app
 }
 
+// directoryListTestRoot is the directory of the mock file system that contains the directories
+// and files of a directoryListFixture.
+const directoryListTestRoot = "/src"
+
+// directoryListFixture describes the directories and files that getDirectoryListForTest creates
+// a DirectoryList with. Paths are slash separated and relative to the root of the fixture,
+// which is the start directory unless startDir is set. Files map paths to their content. The
+// fixture is created in a mock file system, or in a temporary directory if onDisk is set, for
+// tests of features that read the disk themselves. The layout overrides the layout of the
+// config, withTree sets the tree that the DirectoryList can switch to, withParentColumn sets
+// the column that displays the parent directory in the columns layout, and withPathInput sets
+// the PathInput that replaces the title box.
+type directoryListFixture struct {
+	config           *config.Config
+	layout           string
+	startDir         string
+	dirs             []string
+	files            map[string]string
+	onDisk           bool
+	withTree         bool
+	withParentColumn bool
+	withPathInput    bool
+	withHistory      bool
+	// bookmarkStore adds the bookmarks page and dialog to the fixture when it is set.
+	bookmarkStore *mock.BookmarkStore
+}
+
+// getDirectoryListForTest creates and initializes a DirectoryList for the fixture. Updates that
+// background jobs queue are sent to the returned channel instead of the application. In the
+// mock file system, the Git status and total size of directories are not displayed, since
+// they are read from the disk.
+func getDirectoryListForTest(t *testing.T, fixture directoryListFixture) (*DirectoryList, chan func()) {
+	appConfig := fixture.config
+	if appConfig == nil {
+		appConfig = config.Default()
+	}
+	if fixture.layout != "" {
+		appConfig.Layout = fixture.layout
+	}
+
+	var (
+		root    string
+		dirCtrl *dirctrl.DefaultDirectoryController
+	)
+	if fixture.onDisk {
+		root, dirCtrl = t.TempDir(), dirctrl.NewDefaultDirectoryController()
+		createDirectoryListFixtureOnDisk(t, root, fixture)
+	} else {
+		root, dirCtrl = filepath.FromSlash(directoryListTestRoot), getDirectoryControllerForMockFixture(fixture)
+		appConfig.Info.GitStatus = false
+		appConfig.Info.TotalSize = false
+	}
+
+	appOptions := &options.AppOptions{
+		Config:         appConfig,
+		StartDirectory: filepath.Join(root, filepath.FromSlash(fixture.startDir)),
+	}
+
+	screen := tcell.NewSimulationScreen("")
+	app := getAppWithDisabledExitHandlersAndOutputStreams(screen)
+	titleBox := tview.NewTextView()
+	pages := tview.NewPages()
+	list := CreateDirectoryList(app, titleBox, CreateFilterForm(), pages, CreateDetailsView(), dirCtrl, appOptions)
+
+	updates := make(chan func(), 100)
+	list.queueUpdateDraw = func(f func()) {
+		updates <- f
+	}
+
+	if fixture.withTree {
+		tree := CreateDirectoryTree()
+		views := tview.NewPages().
+			AddPage(listViewPage, list, true, true).
+			AddPage(treeViewPage, tree, true, false)
+		list.SetTree(tree, views)
+	}
+
+	if fixture.withParentColumn {
+		list.SetParentColumn(CreateDirectoryColumn())
+	}
+
+	if fixture.withPathInput {
+		pathInput := CreatePathInput()
+		header := tview.NewPages().
+			AddPage(titleBoxPage, titleBox, true, true).
+			AddPage(pathInputPage, pathInput, true, false)
+		list.SetPathInput(pathInput, header)
+	}
+
+	if fixture.withHistory {
+		historyList := CreatePathList(historyTitle, "")
+		pages.AddPage("History", historyList, true, false)
+		list.SetHistoryList(historyList)
+	}
+
+	if fixture.bookmarkStore != nil {
+		bookmarks := CreatePathList(bookmarksTitle, "")
+		form := CreateBookmarkForm()
+		pages.
+			AddPage("Bookmarks", bookmarks, true, false).
+			AddPage("Bookmark", form, true, false)
+		list.SetBookmarks(bookmarks, form, fixture.bookmarkStore)
+	}
+
+	return list.Init(), updates
+}
+
+// getDirectoryControllerForMockFixture returns a DirectoryController for a mock file system
+// that contains the directories and files of the fixture below directoryListTestRoot.
+func getDirectoryControllerForMockFixture(fixture directoryListFixture) *dirctrl.DefaultDirectoryController {
+	fileSystem := mock.NewMockFileSystemWithPaths(directoryListTestRoot)
+	for _, dir := range fixture.dirs {
+		fileSystem.AddDirectory(path.Join(directoryListTestRoot, dir))
+	}
+	for name, content := range fixture.files {
+		fileSystem.AddFile(path.Join(directoryListTestRoot, name), int64(len(content)))
+	}
+
+	dirCtrl := getDirectoryControllerWithMockCommands(fileSystem)
+	dirCtrl.Commands.(*mock.DirectoryCommands).GetAbsolutePathFunc = func(dir string) (string, error) {
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(filepath.FromSlash(directoryListTestRoot), dir)
+		}
+		return filepath.Clean(dir), nil
+	}
+
+	return dirCtrl
+}
+
+// createDirectoryListFixtureOnDisk creates the directories and files of the fixture in the root.
+func createDirectoryListFixtureOnDisk(t *testing.T, root string, fixture directoryListFixture) {
+	for _, dir := range fixture.dirs {
+		if err := os.MkdirAll(filepath.Join(root, filepath.FromSlash(dir)), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for name, content := range fixture.files {
+		filePath := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
 func Test_DirectoryList_handleDetailsInputCapture_SetsFocusToListWhenTabPressed(t *testing.T) {
 	screen := tcell.NewSimulationScreen("")
 	app := getAppWithDisabledExitHandlersAndOutputStreams(screen)
@@ -199,7 +359,7 @@ func Test_DirectoryList_handleDetailsInputCapture_AppExitsWhenShortcutKeyIsPress
 	app := getAppWithDisabledExitHandlersAndOutputStreams(screen)
 	list := CreateDirectoryList(app, nil, nil, nil, nil, nil, nil)
 	exited := false
-	app.handleNormalExit = func() {
+	app.handleCancelExit = func() {
 		exited = true
 	}
 
@@ -551,7 +711,7 @@ func Test_DirectoryList_handleInputCapture_LeftArrowKeyNavigatesToPreviousDirect
 // This ensures that we run tests with the minimum necessary setup.
 func initializeCurrentDirectoryForTest(list *DirectoryList) error {
 	var err error
-	list.currentDir, err = list.dirUtil.GetInitialDirectory()
+	list.currentDir, err = list.dirUtil.GetInitialDirectory("")
 
 	return err
 }
@@ -1375,6 +1535,25 @@ func Test_DirectoryList_addNavigableItem_AddsListItemWhenFilterTextMatchesDirect
 	}
 }
 
+func Test_DirectoryList_addNavigableItem_DoesNotAddHiddenDirectoriesWhenConfiguredToHideThem(t *testing.T) {
+	screen := tcell.NewSimulationScreen("")
+	app := getAppWithDisabledExitHandlersAndOutputStreams(screen)
+	appOptions := &options.AppOptions{Config: config.Default()}
+	appOptions.Config.ShowHidden = false
+	list := CreateDirectoryList(app, tview.NewTextView(), CreateFilterForm(), tview.NewPages(), CreateDetailsView(), nil, appOptions)
+
+	list.addNavigableItem(".git")
+	list.addNavigableItem("src")
+
+	if list.GetItemCount() != 1 {
+		t.Fatalf("Expected only one item in the list, got %d instead", list.GetItemCount())
+	}
+
+	if item, _ := list.GetItemText(0); item != "src" {
+		t.Errorf("Expected the only item to be 'src', got '%s' instead", item)
+	}
+}
+
 func Test_DirectoryList_addNavigableItem_AddsListItemWhenFilterTextMatchesGlobPattern(t *testing.T) {
 	screen := tcell.NewSimulationScreen("")
 	app := getAppWithDisabledExitHandlersAndOutputStreams(screen)
@@ -1641,4 +1820,823 @@ func Test_DirectoryList_handleHelpSelection_HelpShortcutSetsDetailsViewTitle(t *
 	if result != detailsHelpTitle {
 		t.Errorf("Expected details view title to be '%s', got '%s' instead", detailsHelpTitle, result)
 	}
-}
\ No newline at end of file
+}
+func Test_DirectoryList_handleInputCapture_RunsActionBoundToModifiedKey(t *testing.T) {
+	appConfig, err := config.Parse("config.json", []byte(`{"keyBindings": {"help": "Ctrl+G"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mockFileSystem := mock.NewMockFileSystem(nil, 2, 10)
+	dirCtrl := getDirectoryControllerWithMockCommands(mockFileSystem)
+	screen := tcell.NewSimulationScreen("")
+	app := getAppWithDisabledExitHandlersAndOutputStreams(screen)
+	details := CreateDetailsView()
+	appOptions := &options.AppOptions{Config: appConfig}
+
+	list := CreateDirectoryList(app, tview.NewTextView(), CreateFilterForm(), tview.NewPages(), details, dirCtrl, appOptions).Init()
+
+	result := list.handleInputCapture(tcell.NewEventKey(tcell.KeyCtrlG, 0, tcell.ModCtrl))
+
+	if result != nil {
+		t.Errorf("Did not expect the handled event '%v' to be returned", result.Name())
+	}
+
+	if details.GetTitle() != detailsHelpTitle {
+		t.Errorf("Expected details view title to be '%s', got '%s' instead", detailsHelpTitle, details.GetTitle())
+	}
+}
+
+func getRecentDirectoryStoreForTest(visited *[]string, entries ...frecency.Entry) *mock.RecentDirectoryStore {
+	return &mock.RecentDirectoryStore{
+		AddFunc: func(directory string) error {
+			*visited = append(*visited, directory)
+			return nil
+		},
+		RankedFunc: func() ([]frecency.Entry, error) {
+			return entries, nil
+		},
+	}
+}
+
+func Test_DirectoryList_getNavigableItemSelectionHandler_RecordsVisitToDirectory(t *testing.T) {
+	var visited []string
+	screen := tcell.NewSimulationScreen("")
+	app := getAppWithDisabledExitHandlersAndOutputStreams(screen)
+	list := CreateDirectoryList(app, tview.NewTextView(), CreateFilterForm(), tview.NewPages(), CreateDetailsView(), nil, nil).
+		SetRecentDirectories(CreatePathList(recentTitle, "No recently visited directories"), getRecentDirectoryStoreForTest(&visited))
+
+	currentDir := mock.NormalizePath("/oranges/apples")
+	list.currentDir = currentDir
+
+	list.getNavigableItemSelectionHandler("bananas")()
+
+	expected := currentDir + dirctrl.OsPathSeparator + "bananas"
+	if len(visited) != 1 || visited[0] != expected {
+		t.Errorf("Expected a visit to '%s' to be recorded, got '%v' instead", expected, visited)
+	}
+}
+
+func Test_DirectoryList_handleEnterDirectorySelection_PrintsPathWhenRecordingFails(t *testing.T) {
+	var out bytes.Buffer
+	screen := tcell.NewSimulationScreen("")
+	app := getAppWithDisabledExitHandlersAndOutputStreams(screen)
+	app.outputStream = &out
+	store := &mock.RecentDirectoryStore{
+		AddFunc: func(directory string) error {
+			return errors.New("error triggered by test")
+		},
+	}
+	list := CreateDirectoryList(app, tview.NewTextView(), CreateFilterForm(), tview.NewPages(), CreateDetailsView(), nil, nil).
+		SetRecentDirectories(CreatePathList(recentTitle, "No recently visited directories"), store)
+	list.currentDir = mock.NormalizePath("/oranges")
+
+	log.SetOutput(io.Discard)
+	list.handleEnterDirectorySelection()
+
+	if out.String() != list.currentDir {
+		t.Errorf("Expected the path to be '%s', got '%s' instead", list.currentDir, out.String())
+	}
+}
+
+func countItems(list *DirectoryList, itemText string) int {
+	count := 0
+	for i := 0; i < list.GetItemCount(); i++ {
+		if item, _ := list.GetItemText(i); item == itemText {
+			count++
+		}
+	}
+
+	return count
+}
+
+func Test_DirectoryList_load_AddsRecentItemOnlyWhenRecentDirectoriesAreSet(t *testing.T) {
+	mockFileSystem := mock.NewMockFileSystem(nil, 2, 10)
+	dirCtrl := getDirectoryControllerWithMockCommands(mockFileSystem)
+	screen := tcell.NewSimulationScreen("")
+	app := getAppWithDisabledExitHandlersAndOutputStreams(screen)
+
+	list := CreateDirectoryList(app, tview.NewTextView(), CreateFilterForm(), tview.NewPages(), CreateDetailsView(), dirCtrl, nil).Init()
+
+	if countItems(list, listItemRecent) != 0 {
+		t.Errorf("Did not expect the '%s' item without recent directories", listItemRecent)
+	}
+
+	var visited []string
+	list.SetRecentDirectories(CreatePathList(recentTitle, "No recently visited directories"), getRecentDirectoryStoreForTest(&visited)).load()
+
+	if countItems(list, listItemRecent) != 1 {
+		t.Errorf("Expected the '%s' item to be in the list", listItemRecent)
+	}
+}
+
+func Test_DirectoryList_handleRecentSelection_ShowsRankedDirectories(t *testing.T) {
+	var visited []string
+	mockFileSystem := mock.NewMockFileSystem(nil, 2, 10)
+	dirCtrl := getDirectoryControllerWithMockCommands(mockFileSystem)
+	screen := tcell.NewSimulationScreen("")
+	app := getAppWithDisabledExitHandlersAndOutputStreams(screen)
+	recent := CreatePathList(recentTitle, "No recently visited directories")
+	pages := tview.NewPages().AddPage("Recent", recent, true, false)
+	store := getRecentDirectoryStoreForTest(&visited,
+		frecency.Entry{Path: mock.NormalizePath("/apples")},
+		frecency.Entry{Path: mock.NormalizePath("/oranges")})
+
+	list := CreateDirectoryList(app, tview.NewTextView(), CreateFilterForm(), pages, CreateDetailsView(), dirCtrl, nil).
+		SetRecentDirectories(recent, store).
+		Init()
+
+	list.handleRecentSelection()
+
+	if name, _ := pages.GetFrontPage(); name != "Recent" {
+		t.Errorf("Expected the 'Recent' page to be shown, got '%s' instead", name)
+	}
+
+	if app.GetFocus() != recent {
+		t.Error("Expected the recent directories to have focus")
+	}
+
+	if item, _ := recent.GetItemText(1); recent.GetItemCount() != 2 || item != mock.NormalizePath("/oranges") {
+		t.Errorf("Expected the recent directories in ranked order, got %d items", recent.GetItemCount())
+	}
+}
+
+func Test_DirectoryList_handlePathListNavigation_NavigatesToDirectory(t *testing.T) {
+	var visited []string
+	seedDirectories := mock.GetHierarchicalSeedDirectories()
+	mockFileSystem := mock.NewMockFileSystem(seedDirectories, 2, 10)
+	dirCtrl := getDirectoryControllerWithMockCommands(mockFileSystem)
+	screen := tcell.NewSimulationScreen("")
+	app := getAppWithDisabledExitHandlersAndOutputStreams(screen)
+	recent := CreatePathList(recentTitle, "No recently visited directories")
+	pages := tview.NewPages().AddPage("Recent", recent, true, false)
+
+	list := CreateDirectoryList(app, tview.NewTextView(), CreateFilterForm(), pages, CreateDetailsView(), dirCtrl, nil).
+		SetRecentDirectories(recent, getRecentDirectoryStoreForTest(&visited)).
+		Init()
+
+	list.handleRecentSelection()
+	expectedDir := mock.NormalizePath("/testA/testB")
+	list.handlePathListNavigation(expectedDir)
+
+	if list.currentDir != expectedDir {
+		t.Errorf("Expected the current directory to be '%s', got '%s' instead", expectedDir, list.currentDir)
+	}
+
+	if app.GetFocus() != list {
+		t.Error("Expected the directory list to have focus after navigating")
+	}
+
+	if len(visited) != 0 {
+		t.Errorf("Did not expect a visit to be recorded without exiting, got '%v'", visited)
+	}
+}
+
+func Test_DirectoryList_handleBookmarkSelection_BookmarksSelectedDirectory(t *testing.T) {
+	store := &mock.BookmarkStore{}
+	list, _ := getDirectoryListForTest(t, directoryListFixture{
+		dirs:          []string{"api", "web"},
+		bookmarkStore: store,
+	})
+	setSelectedItem(list, "web")
+
+	list.handleBookmarkSelection()
+
+	if name, _ := list.pages.GetFrontPage(); name != "Bookmark" || !list.bookmarkForm.HasFocus() {
+		t.Fatalf("Expected the bookmark dialog to be shown and focused, got page '%s'", name)
+	}
+
+	list.handleBookmarkEntry(tcell.KeyEnter)
+
+	expectedPath := filepath.Join(list.currentDir, "web")
+	if len(store.Bookmarks) != 1 || store.Bookmarks[0].Name != "web" || store.Bookmarks[0].Path != expectedPath {
+		t.Errorf("Expected a bookmark named 'web' for '%s', got '%v' instead", expectedPath, store.Bookmarks)
+	}
+
+	if list.app.GetFocus() != list {
+		t.Error("Expected the directory list to have focus after the bookmark was added")
+	}
+}
+
+func Test_DirectoryList_handleBookmarkSelection_BookmarksCurrentDirectoryWhenMenuItemSelected(t *testing.T) {
+	store := &mock.BookmarkStore{}
+	list, _ := getDirectoryListForTest(t, directoryListFixture{
+		dirs:          []string{"api"},
+		bookmarkStore: store,
+	})
+	setSelectedItem(list, listItemEnterDir)
+
+	list.handleBookmarkSelection()
+	list.handleBookmarkEntry(tcell.KeyEnter)
+
+	if len(store.Bookmarks) != 1 || store.Bookmarks[0].Path != list.currentDir {
+		t.Errorf("Expected a bookmark for '%s', got '%v' instead", list.currentDir, store.Bookmarks)
+	}
+}
+
+func Test_DirectoryList_handleBookmarkEntry_DoesNotAddBookmarkWhenCancelled(t *testing.T) {
+	store := &mock.BookmarkStore{}
+	list, _ := getDirectoryListForTest(t, directoryListFixture{bookmarkStore: store})
+
+	list.handleBookmarkSelection()
+	list.handleBookmarkEntry(tcell.KeyEsc)
+
+	if len(store.Bookmarks) != 0 {
+		t.Errorf("Did not expect a bookmark to be added, got '%v'", store.Bookmarks)
+	}
+}
+
+func Test_DirectoryList_handleBookmarksSelection_ListsBookmarksAndStaleEntries(t *testing.T) {
+	store := &mock.BookmarkStore{
+		Bookmarks: []bookmark.Bookmark{{Name: "api", Path: "/src/api"}, {Name: "web", Path: "/src/web"}},
+		Stale:     map[string]bool{"/src/web": true},
+	}
+	list, _ := getDirectoryListForTest(t, directoryListFixture{bookmarkStore: store})
+
+	list.handleBookmarksSelection()
+
+	if name, _ := list.pages.GetFrontPage(); name != "Bookmarks" || list.app.GetFocus() != list.bookmarks {
+		t.Fatalf("Expected the bookmarks to be shown and focused, got page '%s'", name)
+	}
+
+	if list.bookmarks.GetItemCount() != 2 {
+		t.Fatalf("Expected 2 bookmarks, got %d instead", list.bookmarks.GetItemCount())
+	}
+
+	if text, _ := list.bookmarks.GetItemText(1); !strings.Contains(text, "(missing)") {
+		t.Errorf("Expected the bookmark of a missing directory to be marked, got '%s' instead", text)
+	}
+}
+
+func Test_DirectoryList_handleBookmarkDeletion_RemovesBookmark(t *testing.T) {
+	store := &mock.BookmarkStore{
+		Bookmarks: []bookmark.Bookmark{{Name: "api", Path: "/src/api"}, {Name: "web", Path: "/src/web"}},
+	}
+	list, _ := getDirectoryListForTest(t, directoryListFixture{bookmarkStore: store})
+	list.handleBookmarksSelection()
+
+	list.handleBookmarkDeletion(PathListItem{Label: "api", Path: "/src/api"})
+
+	if len(store.Bookmarks) != 1 || list.bookmarks.GetItemCount() != 1 {
+		t.Errorf("Expected one bookmark to remain, got '%v'", store.Bookmarks)
+	}
+}
+
+func Test_DirectoryList_handleInputCapture_RunsActionWithoutListItemBoundToCharacter(t *testing.T) {
+	store := &mock.BookmarkStore{}
+	list, _ := getDirectoryListForTest(t, directoryListFixture{bookmarkStore: store})
+
+	result := list.handleInputCapture(tcell.NewEventKey(tcell.KeyRune, 'b', tcell.ModNone))
+
+	if result != nil {
+		t.Errorf("Did not expect the handled event '%v' to be returned", result.Name())
+	}
+
+	if name, _ := list.pages.GetFrontPage(); name != "Bookmark" {
+		t.Errorf("Expected the bookmark dialog to be shown, got page '%s' instead", name)
+	}
+}
+
+func Test_DirectoryList_handleBackSelection_RestoresPreviousDirectoryAndSelection(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{
+		dirs:        []string{"api/v1", "web"},
+		withHistory: true,
+	})
+	startDir := list.currentDir
+	setSelectedItem(list, "api")
+	list.handleRightKeyEvent()
+
+	list.handleBackSelection()
+
+	if list.currentDir != startDir {
+		t.Errorf("Expected the current directory to be '%s', got '%s' instead", startDir, list.currentDir)
+	}
+
+	if item, _ := list.GetItemText(list.GetCurrentItem()); item != "api" {
+		t.Errorf("Expected 'api' to be selected, got '%s' instead", item)
+	}
+}
+
+func Test_DirectoryList_handleBackSelection_RestoresFilter(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{
+		dirs:        []string{"api/v1", "web"},
+		withHistory: true,
+	})
+	list.setFilter(filterMethodBeginsWith, "api*")
+	list.load()
+	setSelectedItem(list, "api")
+	list.handleRightKeyEvent()
+
+	if list.filterText != "" {
+		t.Fatalf("Expected the filter to be cleared after navigating, got '%s' instead", list.filterText)
+	}
+
+	list.handleBackSelection()
+
+	if list.filterText != "api*" {
+		t.Errorf("Expected the filter 'api*' to be restored, got '%s' instead", list.filterText)
+	}
+
+	expectedTitle := fmt.Sprintf("%v - Filter: %v", listTitle, "api*")
+	if list.GetTitle() != expectedTitle {
+		t.Errorf("Expected the title to be '%s', got '%s' instead", expectedTitle, list.GetTitle())
+	}
+}
+
+func Test_DirectoryList_handleForwardSelection_ReturnsToDirectoryAfterGoingBack(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{
+		dirs:        []string{"api/v1", "web"},
+		withHistory: true,
+	})
+	setSelectedItem(list, "api")
+	list.handleRightKeyEvent()
+	childDir := list.currentDir
+
+	list.handleBackSelection()
+	list.handleForwardSelection()
+
+	if list.currentDir != childDir {
+		t.Errorf("Expected the current directory to be '%s', got '%s' instead", childDir, list.currentDir)
+	}
+}
+
+func Test_DirectoryList_handleInputCapture_AltArrowKeysNavigateHistory(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{
+		dirs:        []string{"api/v1", "web"},
+		withHistory: true,
+	})
+	startDir := list.currentDir
+	setSelectedItem(list, "api")
+	list.handleRightKeyEvent()
+	childDir := list.currentDir
+
+	if result := list.handleInputCapture(tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModAlt)); result != nil {
+		t.Errorf("Did not expect the handled event '%v' to be returned", result.Name())
+	}
+
+	if list.currentDir != startDir {
+		t.Errorf("Expected Alt+Left to go back to '%s', got '%s' instead", startDir, list.currentDir)
+	}
+
+	list.handleInputCapture(tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModAlt))
+
+	if list.currentDir != childDir {
+		t.Errorf("Expected Alt+Right to go forward to '%s', got '%s' instead", childDir, list.currentDir)
+	}
+}
+
+func Test_DirectoryList_handleInputCapture_BracketKeysNavigateHistory(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{
+		dirs:        []string{"api/v1", "web"},
+		withHistory: true,
+	})
+	startDir := list.currentDir
+	setSelectedItem(list, "api")
+	list.handleRightKeyEvent()
+
+	list.handleInputCapture(tcell.NewEventKey(tcell.KeyRune, '[', tcell.ModNone))
+
+	if list.currentDir != startDir {
+		t.Errorf("Expected '[' to go back to '%s', got '%s' instead", startDir, list.currentDir)
+	}
+}
+
+func Test_DirectoryList_handleHistorySelection_ListsVisitedDirectories(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{
+		dirs:        []string{"api/v1", "web"},
+		withHistory: true,
+	})
+	setSelectedItem(list, "api")
+	list.handleRightKeyEvent()
+	list.handleLeftKeyEvent()
+
+	list.handleHistorySelection()
+
+	if name, _ := list.pages.GetFrontPage(); name != "History" || list.app.GetFocus() != list.historyList {
+		t.Fatalf("Expected the history to be shown and focused, got page '%s'", name)
+	}
+
+	if list.historyList.GetItemCount() != 3 {
+		t.Errorf("Expected 3 visited directories, got %d instead", list.historyList.GetItemCount())
+	}
+
+	if list.historyList.GetCurrentItem() != 2 {
+		t.Errorf("Expected the current directory to be selected, got item %d instead", list.historyList.GetCurrentItem())
+	}
+}
+
+func Test_DirectoryList_handleHistoryEntrySelection_RestoresSelectedEntry(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{
+		dirs:        []string{"api/v1", "web"},
+		withHistory: true,
+	})
+	setSelectedItem(list, "api")
+	list.handleRightKeyEvent()
+	childDir := list.currentDir
+	list.handleLeftKeyEvent()
+
+	list.handleHistorySelection()
+	list.historyList.SetCurrentItem(1)
+	list.handleHistoryEntrySelection("")
+
+	if list.currentDir != childDir {
+		t.Errorf("Expected the current directory to be '%s', got '%s' instead", childDir, list.currentDir)
+	}
+
+	if list.app.GetFocus() != list {
+		t.Error("Expected the directory list to have focus after selecting an entry")
+	}
+}
+
+func getDirectoryNamesForTest(list *DirectoryList) []string {
+	var names []string
+	for i := 0; i < list.GetItemCount(); i++ {
+		if name := list.getItemName(i); !list.isMenuItem(name) {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+func Test_DirectoryList_load_OrdersFuzzyMatchesByScore(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"caption", "docs", "services-api", "api", "web"}})
+	list.setFilter(filterMethodFuzzy, "api")
+
+	list.load()
+
+	result := getDirectoryNamesForTest(list)
+	expected := []string{"api", "services-api", "caption"}
+	if fmt.Sprint(result) != fmt.Sprint(expected) {
+		t.Errorf("Expected the directories %v, got %v instead", expected, result)
+	}
+}
+
+func Test_DirectoryList_load_HighlightsFuzzyMatches(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"svc-foo-v2"}})
+	list.setFilter(filterMethodFuzzy, "sf2")
+
+	list.load()
+
+	text, _ := list.GetItemText(1)
+	expected := "[::bu]s[::-]vc-[::bu]f[::-]oo-v[::bu]2[::-]"
+	if text != expected {
+		t.Errorf("Expected the item text '%s', got '%s' instead", expected, text)
+	}
+
+	if name := list.getItemName(1); name != "svc-foo-v2" {
+		t.Errorf("Expected the item name 'svc-foo-v2', got '%s' instead", name)
+	}
+}
+
+func Test_DirectoryList_handleRightKeyEvent_NavigatesToHighlightedDirectory(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"svc-foo-v2"}})
+	list.setFilter(filterMethodFuzzy, "sf2")
+	list.load()
+	list.SetCurrentItem(1)
+
+	list.handleRightKeyEvent()
+
+	expected := mock.NormalizePath("/src") + dirctrl.OsPathSeparator + "svc-foo-v2"
+	if list.currentDir != expected {
+		t.Errorf("Expected the current directory to be '%s', got '%s' instead", expected, list.currentDir)
+	}
+}
+
+func Test_DirectoryList_handleFilterEntry_SetsFuzzyFilterTitle(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"api"}})
+	list.filter.SetFilterMethod(config.FilterMethodFuzzy).SetText("api")
+
+	list.handleFilterEntry(tcell.KeyEnter)
+
+	expected := fmt.Sprintf("%v - Fuzzy filter: %v", listTitle, "api")
+	if list.GetTitle() != expected {
+		t.Errorf("Expected the title to be '%s', got '%s' instead", expected, list.GetTitle())
+	}
+}
+
+func Test_highlightRunes_EscapesTextAroundHighlights(t *testing.T) {
+	result := highlightRunes("[red]x", []int{5})
+
+	expected := "[red[]" + "[::bu]x[::-]"
+	if result != expected {
+		t.Errorf("Expected '%s', got '%s' instead", expected, result)
+	}
+}
+
+func Test_highlightRunes_EscapesTextWithoutHighlights(t *testing.T) {
+	result := highlightRunes("[red]x", nil)
+
+	expected := "[red[]x"
+	if result != expected {
+		t.Errorf("Expected '%s', got '%s' instead", expected, result)
+	}
+}
+
+func Test_DirectoryList_handleFilterEntry_EscapesFilterTextInTitle(t *testing.T) {
+	for _, method := range []string{config.FilterMethodFuzzy, config.FilterMethodGlob} {
+		list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"api"}})
+		list.filter.SetFilterMethod(method).SetText("[red]")
+
+		list.handleFilterEntry(tcell.KeyEnter)
+
+		if !strings.Contains(list.GetTitle(), ": [red[]") {
+			t.Errorf("Expected the %s filter text to be escaped in the title, got '%s' instead", method, list.GetTitle())
+		}
+	}
+}
+
+func typeLiveFilterForTest(list *DirectoryList, text string) {
+	for _, r := range text {
+		list.handleInputCapture(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+	}
+}
+
+func Test_DirectoryList_handleLiveFilterInput_NarrowsListWithEveryKeystroke(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"api", "app", "docs"}})
+	list.handleLiveFilterSelection()
+
+	typeLiveFilterForTest(list, "a")
+	if result := getDirectoryNamesForTest(list); fmt.Sprint(result) != fmt.Sprint([]string{"api", "app"}) {
+		t.Errorf("Expected the directories [api app], got %v instead", result)
+	}
+
+	typeLiveFilterForTest(list, "pi")
+	if result := getDirectoryNamesForTest(list); fmt.Sprint(result) != fmt.Sprint([]string{"api"}) {
+		t.Errorf("Expected the directories [api], got %v instead", result)
+	}
+
+	expected := fmt.Sprintf("%v - Live filter: %v_", listTitle, "api")
+	if list.GetTitle() != expected {
+		t.Errorf("Expected the title to be '%s', got '%s' instead", expected, list.GetTitle())
+	}
+}
+
+func Test_DirectoryList_handleLiveFilterInput_SelectsFirstMatch(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"api", "docs"}})
+	list.handleLiveFilterSelection()
+
+	typeLiveFilterForTest(list, "d")
+
+	if name := list.getItemName(list.GetCurrentItem()); name != "docs" {
+		t.Errorf("Expected 'docs' to be selected, got '%s' instead", name)
+	}
+}
+
+func Test_DirectoryList_handleLiveFilterInput_BackspaceRemovesLastCharacter(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"api", "app", "docs"}})
+	list.handleLiveFilterSelection()
+	typeLiveFilterForTest(list, "api")
+
+	result := list.handleInputCapture(tcell.NewEventKey(tcell.KeyBackspace2, 0, tcell.ModNone))
+
+	if result != nil {
+		t.Error("Expected the Backspace key to be handled")
+	}
+	if list.liveQuery != "ap" {
+		t.Errorf("Expected the query to be 'ap', got '%s' instead", list.liveQuery)
+	}
+	if names := getDirectoryNamesForTest(list); fmt.Sprint(names) != fmt.Sprint([]string{"api", "app"}) {
+		t.Errorf("Expected the directories [api app], got %v instead", names)
+	}
+}
+
+func Test_DirectoryList_handleLiveFilterInput_EscClearsFilter(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"api", "docs"}})
+	list.handleLiveFilterSelection()
+	typeLiveFilterForTest(list, "api")
+
+	list.handleInputCapture(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone))
+
+	if list.liveFilter {
+		t.Error("Expected the live filter to stop")
+	}
+	if list.GetTitle() != listTitle {
+		t.Errorf("Expected the title to be '%s', got '%s' instead", listTitle, list.GetTitle())
+	}
+	if names := getDirectoryNamesForTest(list); fmt.Sprint(names) != fmt.Sprint([]string{"api", "docs"}) {
+		t.Errorf("Expected the directories [api docs], got %v instead", names)
+	}
+}
+
+func Test_DirectoryList_handleLiveFilterInput_ArrowKeysKeepNavigating(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"api", "app"}})
+	list.handleLiveFilterSelection()
+	typeLiveFilterForTest(list, "a")
+
+	result := list.handleLiveFilterInput(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone))
+
+	if result {
+		t.Error("Expected the Down key to be passed on to the list")
+	}
+}
+
+func Test_DirectoryList_handleLiveFilterInput_UsesSelectedFilterMethod(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"api", "services-api"}})
+	list.filter.SetFilterMethod(config.FilterMethodEndsWith)
+	list.handleLiveFilterSelection()
+
+	typeLiveFilterForTest(list, "api")
+
+	if names := getDirectoryNamesForTest(list); fmt.Sprint(names) != fmt.Sprint([]string{"api", "services-api"}) {
+		t.Errorf("Expected the directories [api services-api], got %v instead", names)
+	}
+}
+
+func Test_DirectoryList_load_FiltersByRegex(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"svc-foo-v2", "svc-bar", "svc-foo-v10", "docs"}})
+	list.setFilter(filterMethodRegex, `^svc-\w+-v\d$`)
+
+	list.load()
+
+	if names := getDirectoryNamesForTest(list); fmt.Sprint(names) != fmt.Sprint([]string{"svc-foo-v2"}) {
+		t.Errorf("Expected the directories [svc-foo-v2], got %v instead", names)
+	}
+	if text, _ := list.GetItemText(1); text != "[::bu]svc-foo-v2[::-]" {
+		t.Errorf("Expected the match to be highlighted, got '%s' instead", text)
+	}
+}
+
+func Test_DirectoryList_setFilter_ShowsErrorForInvalidRegex(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"svc-foo-v2"}})
+	list.setFilter(filterMethodRegex, "svc-(")
+
+	list.load()
+
+	if names := getDirectoryNamesForTest(list); len(names) != 0 {
+		t.Errorf("Expected no directories, got %v instead", names)
+	}
+
+	expected := fmt.Sprintf("%v - Regex filter: %v [red]missing closing )[-]", listTitle, "svc-(")
+	if list.GetTitle() != expected {
+		t.Errorf("Expected the title to be '%s', got '%s' instead", expected, list.GetTitle())
+	}
+}
+
+func Test_DirectoryList_load_IgnoresCaseForEveryFilterMethod(t *testing.T) {
+	testCases := map[int]string{
+		filterMethodBeginsWith:  "SVC*",
+		filterMethodGlobPattern: "S?C-*",
+		filterMethodFuzzy:       "SF",
+		filterMethodRegex:       "^SVC",
+	}
+
+	for filterMethod, filterText := range testCases {
+		list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"svc-foo", "docs"}})
+		list.ignoreCase = true
+		list.setFilter(filterMethod, filterText)
+
+		list.load()
+
+		if names := getDirectoryNamesForTest(list); fmt.Sprint(names) != fmt.Sprint([]string{"svc-foo"}) {
+			t.Errorf("Expected filter method %d to match [svc-foo], got %v instead", filterMethod, names)
+		}
+	}
+}
+
+func Test_DirectoryList_handleFilterEntry_AppliesIgnoreCaseOfFilterForm(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"API", "docs"}})
+	list.filter.SetCaseInsensitive(true).SetText("api")
+
+	list.handleFilterEntry(tcell.KeyEnter)
+
+	if names := getDirectoryNamesForTest(list); fmt.Sprint(names) != fmt.Sprint([]string{"API"}) {
+		t.Errorf("Expected the directories [API], got %v instead", names)
+	}
+
+	expected := fmt.Sprintf("%v - Filter: %v (ignoring case)", listTitle, "api*")
+	if list.GetTitle() != expected {
+		t.Errorf("Expected the title to be '%s', got '%s' instead", expected, list.GetTitle())
+	}
+}
+
+func Test_DirectoryList_handleToggleHiddenSelection_HidesDirectoriesAndCountsThemInTitle(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{".git", ".idea", "src"}})
+
+	list.handleToggleHiddenSelection()
+
+	if names := getDirectoryNamesForTest(list); fmt.Sprint(names) != fmt.Sprint([]string{"src"}) {
+		t.Errorf("Expected the directories [src], got %v instead", names)
+	}
+
+	expected := fmt.Sprintf("%v (2 hidden)", listTitle)
+	if list.GetTitle() != expected {
+		t.Errorf("Expected the title to be '%s', got '%s' instead", expected, list.GetTitle())
+	}
+
+	list.handleToggleHiddenSelection()
+
+	if names := getDirectoryNamesForTest(list); fmt.Sprint(names) != fmt.Sprint([]string{".git", ".idea", "src"}) {
+		t.Errorf("Expected the directories [.git .idea src], got %v instead", names)
+	}
+	if list.GetTitle() != listTitle {
+		t.Errorf("Expected the title to be '%s', got '%s' instead", listTitle, list.GetTitle())
+	}
+}
+
+func Test_DirectoryList_handleToggleHiddenSelection_HidesFilesInDetailsPane(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"src"}})
+	dirCtrl := list.dirUtil.(*dirctrl.DefaultDirectoryController)
+	dirCtrl.Commands.(*mock.DirectoryCommands).ReadDirectoryFunc = func(dirname string) ([]fs.FileInfo, error) {
+		return []fs.FileInfo{
+			mock.File{FileName: ".env", FileMode: fs.ModePerm},
+			mock.File{FileName: "main.go", FileMode: fs.ModePerm},
+		}, nil
+	}
+	list.load()
+
+	list.handleToggleHiddenSelection()
+
+	if dirCtrl.ShowHidden {
+		t.Error("Expected the directory controller to hide hidden files")
+	}
+	if text := list.details.GetText(true); strings.Contains(text, ".env") || !strings.Contains(text, "main.go") {
+		t.Errorf("Expected the details pane to list only visible files, got the following instead:\n%s\n", text)
+	}
+}
+
+func Test_DirectoryList_handleToggleHiddenSelection_KeepsSelectedItem(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{".git", "docs", "src"}})
+	setSelectedItem(list, "src")
+
+	list.handleToggleHiddenSelection()
+
+	if name := list.getItemName(list.GetCurrentItem()); name != "src" {
+		t.Errorf("Expected 'src' to remain selected, got '%s' instead", name)
+	}
+}
+
+func Test_DirectoryList_handleToggleIgnoredSelection_ShowsIgnoredDirectoriesDimmed(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"node_modules", "src"}})
+	list.dirUtil.(*dirctrl.DefaultDirectoryController).Ignore = dirctrl.NewIgnoreRules([]string{"node_modules"})
+	list.load()
+
+	if names := getDirectoryNamesForTest(list); fmt.Sprint(names) != fmt.Sprint([]string{"src"}) {
+		t.Errorf("Expected the directories [src], got %v instead", names)
+	}
+
+	list.handleToggleIgnoredSelection()
+
+	if names := getDirectoryNamesForTest(list); fmt.Sprint(names) != fmt.Sprint([]string{"node_modules", "src"}) {
+		t.Errorf("Expected the directories [node_modules src], got %v instead", names)
+	}
+	if text, _ := list.GetItemText(1); text != "[gray]node_modules[-]" {
+		t.Errorf("Expected the ignored directory to be dimmed, got '%s' instead", text)
+	}
+	if text, _ := list.GetItemText(2); text != "src" {
+		t.Errorf("Expected the directory not to be dimmed, got '%s' instead", text)
+	}
+
+	expected := fmt.Sprintf("%v (showing ignored)", listTitle)
+	if list.GetTitle() != expected {
+		t.Errorf("Expected the title to be '%s', got '%s' instead", expected, list.GetTitle())
+	}
+
+	list.handleToggleIgnoredSelection()
+
+	if names := getDirectoryNamesForTest(list); fmt.Sprint(names) != fmt.Sprint([]string{"src"}) {
+		t.Errorf("Expected the directories [src], got %v instead", names)
+	}
+}
+
+func Test_DirectoryList_handleCycleSortSelection_SortsByNextModeAndShowsItInTitle(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"src"}})
+	commands := list.dirUtil.(*dirctrl.DefaultDirectoryController).Commands.(*mock.DirectoryCommands)
+	list.load()
+
+	list.handleCycleSortSelection()
+
+	if commands.SortMode != config.SortModeNatural {
+		t.Errorf("Expected the sort mode to be '%s', got '%s' instead", config.SortModeNatural, commands.SortMode)
+	}
+
+	expected := fmt.Sprintf("%v (sort: %v)", listTitle, config.SortModeNatural)
+	if list.GetTitle() != expected {
+		t.Errorf("Expected the title to be '%s', got '%s' instead", expected, list.GetTitle())
+	}
+
+	for range config.SortModes[1:] {
+		list.handleCycleSortSelection()
+	}
+
+	if commands.SortMode != config.SortModeName || list.GetTitle() != listTitle {
+		t.Errorf("Expected the sort mode to cycle back to '%s', got '%s' with the title '%s' instead", config.SortModeName, commands.SortMode, list.GetTitle())
+	}
+}
+
+func Test_DirectoryList_handleReverseSortSelection_ReversesSortOrder(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"src"}})
+	commands := list.dirUtil.(*dirctrl.DefaultDirectoryController).Commands.(*mock.DirectoryCommands)
+	list.load()
+
+	list.handleReverseSortSelection()
+
+	if !commands.SortReverse {
+		t.Error("Expected the sort order to be reversed")
+	}
+
+	expected := fmt.Sprintf("%v (sort: %v reversed)", listTitle, config.SortModeName)
+	if list.GetTitle() != expected {
+		t.Errorf("Expected the title to be '%s', got '%s' instead", expected, list.GetTitle())
+	}
+}
@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"fmt"
+	"github.com/rivo/tview"
+	"regexp"
+	"strings"
+)
+
+// markdownCodeIndent indents the lines of code blocks to set them apart from the text.
+const markdownCodeIndent = "    "
+
+var (
+	markdownHeading     = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	markdownRule        = regexp.MustCompile(`^\s*([-*_])(\s*([-*_])){2,}\s*$`)
+	markdownBullet      = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	markdownOrdered     = regexp.MustCompile(`^(\s*)(\d+[.)])\s+(.*)$`)
+	markdownQuote       = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	markdownFenceMarker = regexp.MustCompile("^\\s*(```|~~~)\\s*([\\w+-]*)")
+)
+
+// renderMarkdown converts the basic markdown of the text, which is headings, emphasis, code,
+// lists, quotes, rules and links, to the color tags of tview. Lines are not reflowed. Code
+// blocks whose language is known are highlighted.
+func renderMarkdown(text string) string {
+	var (
+		lines     []string
+		code      []string
+		fence     string
+		codeLang  string
+		isInFence bool
+	)
+
+	flushCode := func() {
+		highlighted := tview.Escape(strings.Join(code, "\n"))
+		if codeLang != "" {
+			highlighted = getHighlightedText("code."+codeLang, strings.Join(code, "\n"))
+		}
+		for _, line := range strings.Split(highlighted, "\n") {
+			lines = append(lines, markdownCodeIndent+line)
+		}
+		code = nil
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+		if match := markdownFenceMarker.FindStringSubmatch(line); match != nil && (!isInFence || match[1] == fence) {
+			if isInFence {
+				flushCode()
+			}
+			isInFence = !isInFence
+			fence, codeLang = match[1], strings.ToLower(match[2])
+			continue
+		}
+
+		if isInFence {
+			code = append(code, line)
+			continue
+		}
+
+		lines = append(lines, renderMarkdownLine(line))
+	}
+
+	if isInFence {
+		flushCode()
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// renderMarkdownLine converts a line of markdown outside of code blocks.
+func renderMarkdownLine(line string) string {
+	if match := markdownHeading.FindStringSubmatch(line); match != nil {
+		style := "[yellow::b]"
+		if len(match[1]) == 1 {
+			style = "[yellow::bu]"
+		} else if len(match[1]) > 2 {
+			style = "[::b]"
+		}
+		return style + tview.Escape(match[2]) + "[-::-]"
+	}
+
+	if markdownRule.MatchString(line) {
+		return "[gray]" + strings.Repeat("─", 40) + "[-]"
+	}
+
+	if match := markdownBullet.FindStringSubmatch(line); match != nil {
+		return match[1] + "[yellow]•[-] " + renderMarkdownInline(match[2])
+	}
+
+	if match := markdownOrdered.FindStringSubmatch(line); match != nil {
+		return fmt.Sprintf("%v[yellow]%v[-] %v", match[1], match[2], renderMarkdownInline(match[3]))
+	}
+
+	if match := markdownQuote.FindStringSubmatch(line); match != nil {
+		return "[gray]│[-] " + renderMarkdownInline(match[1])
+	}
+
+	return renderMarkdownInline(line)
+}
+
+// renderMarkdownInline converts the code spans, bold text and links of the text. Everything
+// else is escaped.
+func renderMarkdownInline(text string) string {
+	var (
+		builder strings.Builder
+		literal strings.Builder
+	)
+
+	write := func(tagged string) {
+		builder.WriteString(tview.Escape(literal.String()))
+		literal.Reset()
+		builder.WriteString(tagged)
+	}
+
+	for i := 0; i < len(text); {
+		rest := text[i:]
+
+		if rest[0] == '`' {
+			if end := strings.IndexByte(rest[1:], '`'); end >= 0 {
+				write("[aqua]" + tview.Escape(rest[1:1+end]) + "[-]")
+				i += end + 2
+				continue
+			}
+		}
+
+		if strings.HasPrefix(rest, "**") || strings.HasPrefix(rest, "__") {
+			if end := strings.Index(rest[2:], rest[:2]); end > 0 {
+				write("[::b]" + renderMarkdownInline(rest[2:2+end]) + "[::-]")
+				i += end + 4
+				continue
+			}
+		}
+
+		if rest[0] == '[' {
+			if labelEnd := strings.Index(rest, "]("); labelEnd > 0 {
+				if end := strings.IndexByte(rest[labelEnd:], ')'); end > 0 {
+					write("[::u]" + renderMarkdownInline(rest[1:labelEnd]) + "[::-]")
+					i += labelEnd + end + 1
+					continue
+				}
+			}
+		}
+
+		literal.WriteByte(rest[0])
+		i++
+	}
+
+	write("")
+
+	return builder.String()
+}
@@ -0,0 +1,38 @@
+package ui
+
+import "testing"
+
+func Test_renderMarkdown_StylesHeadingsListsAndQuotes(t *testing.T) {
+	text := "# ci\n## Usage ##\n- first\n  * nested\n2. second\n> note\n---\n"
+
+	expected := "[yellow::bu]ci[-::-]\n" +
+		"[yellow::b]Usage[-::-]\n" +
+		"[yellow]•[-] first\n" +
+		"  [yellow]•[-] nested\n" +
+		"[yellow]2.[-] second\n" +
+		"[gray]│[-] note\n" +
+		"[gray]────────────────────────────────────────[-]\n"
+	if result := renderMarkdown(text); result != expected {
+		t.Errorf("Expected the following markdown:\n%s\nGot the following instead:\n%s\n", expected, result)
+	}
+}
+
+func Test_renderMarkdown_IndentsAndHighlightsCodeBlocks(t *testing.T) {
+	text := "Run:\n```go\nfunc main() {}\n```\n~~~\n# not a heading\n~~~\n"
+
+	expected := "Run:\n" +
+		"    [yellow]func[-] main() {}\n" +
+		"    # not a heading\n"
+	if result := renderMarkdown(text); result != expected {
+		t.Errorf("Expected the following markdown:\n%s\nGot the following instead:\n%s\n", expected, result)
+	}
+}
+
+func Test_renderMarkdownInline_StylesCodeBoldAndLinksAndEscapesTags(t *testing.T) {
+	text := "Use **ci** with `cd [dir]` as [docs](https://example.com) say [red]"
+
+	expected := "Use [::b]ci[::-] with [aqua]cd [dir[][-] as [::u]docs[::-] say [red[]"
+	if result := renderMarkdownInline(text); result != expected {
+		t.Errorf("Expected '%s', got '%s' instead", expected, result)
+	}
+}
@@ -0,0 +1,268 @@
+package ui
+
+import (
+	"fmt"
+	"github.com/gdamore/tcell/v2"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/dirctrl"
+	"github.com/rivo/tview"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+const pathInputTitle = "Go to Directory"
+
+const (
+	titleBoxPage  = "Title"
+	pathInputPage = "Path"
+)
+
+// PathInput provides the user interface that enables the user to type the path of a directory
+// in place of the title box. Tab completes the names of directories, and the candidates are
+// displayed below the input when there is more than one.
+type PathInput struct {
+	*tview.InputField
+	candidates      []string
+	completeHandler func(text string) (completedText string, candidates []string)
+	doneHandler     func(key tcell.Key)
+}
+
+// CreatePathInput creates a new instance of PathInput.
+func CreatePathInput() *PathInput {
+	pathInput := &PathInput{
+		InputField: tview.NewInputField(),
+	}
+
+	pathInput.SetFieldBackgroundColor(tcell.ColorDefault).
+		SetAutocompleteFunc(pathInput.handleAutocomplete).
+		SetChangedFunc(pathInput.handleTextChange).
+		SetDoneFunc(pathInput.handleDone).
+		SetInputCapture(pathInput.handleInputCapture)
+
+	pathInput.SetBorder(true).
+		SetTitle(pathInputTitle).
+		SetBorderPadding(1, 1, 1, 1)
+
+	return pathInput
+}
+
+// SetCompleteHandler sets the function that completes the typed path, and returns the
+// candidates when the completion is ambiguous.
+func (p *PathInput) SetCompleteHandler(handler func(text string) (string, []string)) *PathInput {
+	p.completeHandler = handler
+
+	return p
+}
+
+// SetDoneHandler sets the function that is called when entry of the path is completed or
+// cancelled.
+func (p *PathInput) SetDoneHandler(handler func(key tcell.Key)) *PathInput {
+	p.doneHandler = handler
+
+	return p
+}
+
+// SetPath replaces the typed text with the path and clears the error.
+func (p *PathInput) SetPath(path string) *PathInput {
+	p.SetText(path)
+	p.SetError(nil)
+
+	return p
+}
+
+// SetError displays the error in the title of the PathInput, or removes the error if it is nil.
+func (p *PathInput) SetError(err error) *PathInput {
+	if err == nil {
+		p.SetTitle(pathInputTitle)
+	} else {
+		p.SetTitle(fmt.Sprintf("%v - [red]%v[-]", pathInputTitle, tview.Escape(err.Error())))
+	}
+
+	return p
+}
+
+// handleInputCapture is an event handler that completes the typed path when Tab is pressed.
+func (p *PathInput) handleInputCapture(event *tcell.EventKey) *tcell.EventKey {
+	if event.Key() != tcell.KeyTab {
+		return event
+	}
+
+	if p.completeHandler != nil {
+		text, candidates := p.completeHandler(p.GetText())
+		if text != p.GetText() {
+			p.SetText(text)
+		}
+		p.candidates = candidates
+		p.Autocomplete()
+	}
+
+	return nil
+}
+
+// handleAutocomplete returns the candidates of the last completion for the dropdown of the
+// input field. The input field asks for them again whenever the text changes, so they are
+// returned only once and the dropdown closes as soon as the user continues typing.
+func (p *PathInput) handleAutocomplete(string) []string {
+	candidates := p.candidates
+	p.candidates = nil
+
+	return candidates
+}
+
+// handleTextChange is an event handler that removes the error once the path is edited.
+func (p *PathInput) handleTextChange(string) {
+	p.SetError(nil)
+}
+
+// handleDone is an event handler that triggers when entry of the path is completed or
+// cancelled.
+func (p *PathInput) handleDone(key tcell.Key) {
+	if (key == tcell.KeyEnter || key == tcell.KeyEsc) && p.doneHandler != nil {
+		p.doneHandler(key)
+	}
+}
+
+// hasPathInput determines if the DirectoryList has everything it needs to display the
+// PathInput.
+func (d *DirectoryList) hasPathInput() bool {
+	return d.pathInput != nil && d.header != nil
+}
+
+// handlePathEntrySelection displays the PathInput in place of the title box, filled with the
+// path of the current directory.
+func (d *DirectoryList) handlePathEntrySelection() {
+	if !d.hasPathInput() {
+		return
+	}
+
+	d.stopLiveFilter()
+	d.pathInput.SetPath(d.currentDir + getTrailingSeparator(d.currentDir))
+	d.header.SwitchToPage(pathInputPage)
+	d.app.SetFocus(d.pathInput)
+}
+
+// handlePathEntry is an event handler that navigates to the typed directory when Enter is
+// pressed, or closes the PathInput when Esc is pressed. The PathInput remains open and
+// displays the error if the directory cannot be navigated to.
+func (d *DirectoryList) handlePathEntry(key tcell.Key) {
+	if key == tcell.KeyEnter && strings.TrimSpace(d.pathInput.GetText()) != "" {
+		directory, err := d.resolvePath(d.pathInput.GetText())
+		if err == nil && !d.dirUtil.DirectoryIsAccessible(directory) {
+			err = fmt.Errorf("'%s' does not exist or is inaccessible", directory)
+		}
+		if err != nil {
+			d.pathInput.SetError(err)
+			return
+		}
+
+		if directory != d.currentDir {
+			d.changeDirectory(directory)
+			d.loadDetailsForCurrentDirectory()
+		}
+	}
+
+	d.header.SwitchToPage(titleBoxPage)
+	d.app.SetFocus(d.getView())
+}
+
+// resolvePath returns the absolute path of the typed path. The path may begin with a tilde
+// or contain environment variables, and relative paths are relative to the current directory.
+func (d *DirectoryList) resolvePath(text string) (string, error) {
+	path := dirctrl.ExpandPath(strings.TrimSpace(text))
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(d.currentDir, path)
+	}
+
+	return d.dirUtil.GetAbsolutePath(path)
+}
+
+// completePath completes the name of the last directory of the typed path. A unique match is
+// completed with a trailing path separator, so that the next completion lists its children.
+// Otherwise the name is completed as far as the matches agree, and the matches are returned
+// as the candidates. Only the tilde and environment variables of the completed directories
+// are expanded, and the text is left alone when one of their variables is not set.
+func (d *DirectoryList) completePath(text string) (string, []string) {
+	separator := strings.LastIndexAny(text, `/\`)
+	parent, prefix := text[:separator+1], text[separator+1:]
+
+	parent, ok := expandCompletedPath(parent)
+	if !ok {
+		return text, nil
+	}
+
+	directory, err := d.resolvePath(parent)
+	if err != nil {
+		log.Print(err)
+		return text, nil
+	}
+
+	var names []string
+	if err := d.dirUtil.ScanDirectory(directory, func(dirName string) {
+		if !strings.HasPrefix(dirName, prefix) {
+			return
+		}
+		if !d.showHidden && dirctrl.IsHidden(dirName) && !dirctrl.IsHidden(prefix) {
+			return
+		}
+		names = append(names, dirName)
+	}); err != nil {
+		d.pathInput.SetError(fmt.Errorf("unable to complete '%s'", parent))
+		return text, nil
+	}
+
+	switch len(names) {
+	case 0:
+		return text, nil
+	case 1:
+		return parent + names[0] + dirctrl.OsPathSeparator, nil
+	}
+
+	var candidates []string
+	for _, name := range names {
+		candidates = append(candidates, parent+name)
+	}
+
+	return parent + getCommonPrefix(names), candidates
+}
+
+// expandCompletedPath expands the tilde and environment variables of the path of a directory
+// that the user has finished typing. It reports false when one of the variables is not set.
+func expandCompletedPath(path string) (string, bool) {
+	ok := true
+	os.Expand(path, func(name string) string {
+		if os.Getenv(name) == "" {
+			ok = false
+		}
+		return ""
+	})
+	if !ok {
+		return path, false
+	}
+
+	return dirctrl.ExpandPath(path), true
+}
+
+// getCommonPrefix returns the longest prefix that all the names begin with.
+func getCommonPrefix(names []string) string {
+	prefix := names[0]
+	for _, name := range names[1:] {
+		for !strings.HasPrefix(name, prefix) {
+			_, size := utf8.DecodeLastRuneInString(prefix)
+			prefix = prefix[:len(prefix)-size]
+		}
+	}
+
+	return prefix
+}
+
+// getTrailingSeparator returns the path separator that separates the names of the children
+// of the directory from its path, which is empty for the root directory.
+func getTrailingSeparator(directory string) string {
+	if strings.HasSuffix(directory, dirctrl.OsPathSeparator) {
+		return ""
+	}
+
+	return dirctrl.OsPathSeparator
+}
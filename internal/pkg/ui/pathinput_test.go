@@ -0,0 +1,139 @@
+package ui
+
+import (
+	"fmt"
+	"github.com/gdamore/tcell/v2"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func typePathForTest(list *DirectoryList, path string) {
+	list.handleInputCapture(tcell.NewEventKey(tcell.KeyRune, ':', tcell.ModNone))
+	list.pathInput.SetText(path)
+}
+
+func Test_DirectoryList_handlePathEntrySelection_DisplaysPathInputWithCurrentDirectory(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"src"}, withPathInput: true})
+	root := list.currentDir
+
+	list.handleInputCapture(tcell.NewEventKey(tcell.KeyRune, ':', tcell.ModNone))
+
+	if name, _ := list.header.GetFrontPage(); name != pathInputPage || !list.pathInput.HasFocus() {
+		t.Fatalf("Expected the path input to be shown and focused, got page '%s' instead", name)
+	}
+	if text := list.pathInput.GetText(); text != root+string(os.PathSeparator) {
+		t.Errorf("Expected the path input to contain '%s', got '%s' instead", root+string(os.PathSeparator), text)
+	}
+}
+
+func Test_DirectoryList_completePath_CompletesUniqueMatchWithSeparator(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"services/api", "web"}, withPathInput: true})
+	root := list.currentDir
+
+	text, candidates := list.completePath(filepath.Join(root, "se"))
+
+	expected := filepath.Join(root, "services") + string(os.PathSeparator)
+	if text != expected || candidates != nil {
+		t.Errorf("Expected the completion '%s' without candidates, got '%s' and %v instead", expected, text, candidates)
+	}
+}
+
+func Test_DirectoryList_completePath_ReturnsCandidatesOfAmbiguousMatch(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"project-api", "project-app", "web"}, withPathInput: true})
+
+	text, candidates := list.completePath("pro")
+
+	if text != "project-ap" {
+		t.Errorf("Expected the completion 'project-ap', got '%s' instead", text)
+	}
+	if fmt.Sprint(candidates) != fmt.Sprint([]string{"project-api", "project-app"}) {
+		t.Errorf("Expected the candidates [project-api project-app], got %v instead", candidates)
+	}
+}
+
+func Test_DirectoryList_completePath_ExpandsTilde(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"src"}, withPathInput: true})
+	root := list.currentDir
+	t.Setenv("HOME", root)
+	t.Setenv("USERPROFILE", root)
+
+	text, _ := list.completePath("~" + string(os.PathSeparator) + "s")
+
+	expected := filepath.Join(root, "src") + string(os.PathSeparator)
+	if text != expected {
+		t.Errorf("Expected the completion '%s', got '%s' instead", expected, text)
+	}
+}
+
+func Test_PathInput_handleInputCapture_TabLeavesPartialVariableAlone(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"src"}, withPathInput: true})
+	t.Setenv("HOME", list.currentDir)
+	typePathForTest(list, "$HO")
+
+	list.pathInput.handleInputCapture(tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModNone))
+
+	if text := list.pathInput.GetText(); text != "$HO" {
+		t.Errorf("Expected the path input to contain '$HO', got '%s' instead", text)
+	}
+}
+
+func Test_DirectoryList_completePath_LeavesPathWithUnsetVariableAlone(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"src"}, withPathInput: true})
+	t.Setenv("CI_TEST_UNSET_PATH", "")
+	path := "$CI_TEST_UNSET_PATH" + string(os.PathSeparator) + "s"
+
+	text, candidates := list.completePath(path)
+
+	if text != path || candidates != nil {
+		t.Errorf("Expected the path '%s' without candidates, got '%s' and %v instead", path, text, candidates)
+	}
+}
+
+func Test_PathInput_handleInputCapture_TabCompletesTypedPath(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"services"}, withPathInput: true})
+	root := list.currentDir
+	typePathForTest(list, filepath.Join(root, "ser"))
+
+	list.pathInput.handleInputCapture(tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModNone))
+
+	expected := filepath.Join(root, "services") + string(os.PathSeparator)
+	if text := list.pathInput.GetText(); text != expected {
+		t.Errorf("Expected the path input to contain '%s', got '%s' instead", expected, text)
+	}
+}
+
+func Test_DirectoryList_handlePathEntry_NavigatesToPathWithEnvironmentVariable(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"services/api"}, withPathInput: true})
+	root := list.currentDir
+	t.Setenv("CI_TEST_PATH_ENTRY", filepath.Join(root, "services"))
+	typePathForTest(list, "$CI_TEST_PATH_ENTRY"+string(os.PathSeparator)+"api")
+
+	list.handlePathEntry(tcell.KeyEnter)
+
+	if expected := filepath.Join(root, "services", "api"); list.currentDir != expected {
+		t.Errorf("Expected the current directory to be '%s', got '%s' instead", expected, list.currentDir)
+	}
+	if name, _ := list.header.GetFrontPage(); name != titleBoxPage || !list.HasFocus() {
+		t.Errorf("Expected the title box to be shown and the list focused, got page '%s' instead", name)
+	}
+}
+
+func Test_DirectoryList_handlePathEntry_DisplaysErrorForInvalidPath(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"services"}, withPathInput: true})
+	root := list.currentDir
+	typePathForTest(list, "missing")
+
+	list.handlePathEntry(tcell.KeyEnter)
+
+	if list.currentDir != root {
+		t.Errorf("Expected the current directory to remain '%s', got '%s' instead", root, list.currentDir)
+	}
+	if name, _ := list.header.GetFrontPage(); name != pathInputPage {
+		t.Errorf("Expected the path input to remain shown, got page '%s' instead", name)
+	}
+	if title := list.pathInput.GetTitle(); !strings.Contains(title, "does not exist") {
+		t.Errorf("Expected the title to display the error, got '%s' instead", title)
+	}
+}
@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"fmt"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const (
+	recentTitle    = "Recent Directories"
+	bookmarksTitle = "Bookmarks"
+	historyTitle   = "History"
+)
+
+// PathListItem is a directory in a PathList. Stale items are directories that no longer exist,
+// which are listed but can't be selected.
+type PathListItem struct {
+	Label string
+	Path  string
+	Stale bool
+}
+
+// PathList provides the user interface that lists directories, such as the recently visited
+// ones or the bookmarks, and enables the user to jump to one of them.
+type PathList struct {
+	*tview.List
+	items           []PathListItem
+	emptyText       string
+	selectHandler   func(path string)
+	navigateHandler func(path string)
+	deleteHandler   func(item PathListItem)
+	doneHandler     func()
+}
+
+// CreatePathList creates a new instance of PathList with a title, and the text that it displays
+// when it has no items.
+func CreatePathList(title, emptyText string) *PathList {
+	list := tview.NewList().
+		ShowSecondaryText(false).
+		SetSelectedTextColor(tcell.ColorBlack)
+
+	list.SetBorder(true).
+		SetTitle(title).
+		SetBorderPadding(1, 1, 1, 1)
+
+	pathList := &PathList{
+		List:      list,
+		emptyText: emptyText,
+	}
+
+	list.SetInputCapture(pathList.handleInputCapture)
+
+	return pathList
+}
+
+// Load replaces the items of the PathList.
+func (p *PathList) Load(items []PathListItem) *PathList {
+	p.Clear()
+	p.items = items
+
+	for _, item := range items {
+		path := item.Path
+		text := tview.Escape(path)
+		if item.Label != "" {
+			text = fmt.Sprintf("[yellow]%s[white]  %s", tview.Escape(item.Label), text)
+		}
+
+		if item.Stale {
+			p.AddItem(text+" [red](missing)[white]", "", 0, nil)
+		} else {
+			p.AddItem(text, "", 0, func() {
+				if p.selectHandler != nil {
+					p.selectHandler(path)
+				}
+			})
+		}
+	}
+
+	if len(items) == 0 {
+		p.AddItem(fmt.Sprintf("[gray]%s[white]", p.emptyText), "", 0, p.handleDone)
+	}
+
+	return p
+}
+
+// handleInputCapture is an event handler that processes key events for the PathList.
+func (p *PathList) handleInputCapture(event *tcell.EventKey) *tcell.EventKey {
+	switch event.Key() {
+	case tcell.KeyEscape:
+		p.handleDone()
+		return nil
+	case tcell.KeyRight:
+		if item, exists := p.getSelectedItem(); exists && !item.Stale && p.navigateHandler != nil {
+			p.navigateHandler(item.Path)
+		}
+		return nil
+	case tcell.KeyDelete:
+		if item, exists := p.getSelectedItem(); exists && p.deleteHandler != nil {
+			p.deleteHandler(item)
+		}
+		return nil
+	}
+
+	return event
+}
+
+// handleDone calls the done handler of the PathList if it is set.
+func (p *PathList) handleDone() {
+	if p.doneHandler != nil {
+		p.doneHandler()
+	}
+}
+
+// getSelectedItem returns the selected item, if there is one.
+func (p *PathList) getSelectedItem() (PathListItem, bool) {
+	index := p.GetCurrentItem()
+	if index < 0 || index >= len(p.items) {
+		return PathListItem{}, false
+	}
+
+	return p.items[index], true
+}
+
+// SetSelectHandler sets the handler that is called with the directory of an item when the item
+// is selected with Enter.
+func (p *PathList) SetSelectHandler(handler func(path string)) *PathList {
+	p.selectHandler = handler
+
+	return p
+}
+
+// SetNavigateHandler sets the handler that is called with the directory of the selected item
+// when the right arrow key is pressed.
+func (p *PathList) SetNavigateHandler(handler func(path string)) *PathList {
+	p.navigateHandler = handler
+
+	return p
+}
+
+// SetDeleteHandler sets the handler that is called with the selected item when the Delete key
+// is pressed.
+func (p *PathList) SetDeleteHandler(handler func(item PathListItem)) *PathList {
+	p.deleteHandler = handler
+
+	return p
+}
+
+// SetDoneHandler sets the handler that is called when the PathList is closed with Esc.
+func (p *PathList) SetDoneHandler(handler func()) *PathList {
+	p.doneHandler = handler
+
+	return p
+}
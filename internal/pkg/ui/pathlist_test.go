@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"testing"
+)
+
+func Test_PathList_Load_ShowsEmptyTextWhenThereAreNoItems(t *testing.T) {
+	pathList := CreatePathList(recentTitle, "Nothing here").Load(nil)
+
+	if pathList.GetItemCount() != 1 {
+		t.Fatalf("Expected a single placeholder item, got %d items instead", pathList.GetItemCount())
+	}
+
+	if text, _ := pathList.GetItemText(0); text != "[gray]Nothing here[white]" {
+		t.Errorf("Expected the placeholder to display the empty text, got '%s' instead", text)
+	}
+
+	navigated := false
+	pathList.SetNavigateHandler(func(path string) {
+		navigated = true
+	})
+	pathList.handleInputCapture(tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModNone))
+
+	if navigated {
+		t.Error("Did not expect the placeholder item to be navigable")
+	}
+}
+
+func Test_PathList_Load_DisplaysLabelsAndStaleItems(t *testing.T) {
+	pathList := CreatePathList(bookmarksTitle, "").Load([]PathListItem{
+		{Label: "api", Path: "/src/api"},
+		{Label: "web", Path: "/src/web", Stale: true},
+	})
+
+	if text, _ := pathList.GetItemText(0); text != "[yellow]api[white]  /src/api" {
+		t.Errorf("Expected the label to be displayed before the path, got '%s' instead", text)
+	}
+
+	if text, _ := pathList.GetItemText(1); text != "[yellow]web[white]  /src/web [red](missing)[white]" {
+		t.Errorf("Expected the stale item to be marked as missing, got '%s' instead", text)
+	}
+}
+
+func Test_PathList_handleInputCapture_RightArrowKeyNavigatesToSelectedDirectory(t *testing.T) {
+	var result string
+	pathList := CreatePathList(recentTitle, "").
+		Load([]PathListItem{{Path: "/apples"}, {Path: "/oranges"}}).
+		SetNavigateHandler(func(path string) {
+			result = path
+		})
+	pathList.SetCurrentItem(1)
+
+	if event := pathList.handleInputCapture(tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModNone)); event != nil {
+		t.Errorf("Did not expect the handled event '%v' to be returned", event.Name())
+	}
+
+	if result != "/oranges" {
+		t.Errorf("Expected to navigate to '/oranges', got '%s' instead", result)
+	}
+}
+
+func Test_PathList_handleInputCapture_DoesNotNavigateToStaleItems(t *testing.T) {
+	navigated := false
+	pathList := CreatePathList(bookmarksTitle, "").
+		Load([]PathListItem{{Label: "web", Path: "/src/web", Stale: true}}).
+		SetNavigateHandler(func(path string) {
+			navigated = true
+		})
+
+	pathList.handleInputCapture(tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModNone))
+
+	if navigated {
+		t.Error("Did not expect a stale item to be navigable")
+	}
+}
+
+func Test_PathList_handleInputCapture_DeleteKeyCallsDeleteHandler(t *testing.T) {
+	var result PathListItem
+	pathList := CreatePathList(bookmarksTitle, "").
+		Load([]PathListItem{{Label: "api", Path: "/src/api"}}).
+		SetDeleteHandler(func(item PathListItem) {
+			result = item
+		})
+
+	pathList.handleInputCapture(tcell.NewEventKey(tcell.KeyDelete, 0, tcell.ModNone))
+
+	if result.Label != "api" {
+		t.Errorf("Expected the 'api' item to be deleted, got '%v' instead", result)
+	}
+}
+
+func Test_PathList_handleInputCapture_EscapeKeyCallsDoneHandler(t *testing.T) {
+	done := false
+	pathList := CreatePathList(recentTitle, "").SetDoneHandler(func() {
+		done = true
+	})
+
+	pathList.handleInputCapture(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone))
+
+	if !done {
+		t.Error("Expected the done handler to be called")
+	}
+}
+
+func Test_PathList_Load_SelectingItemCallsSelectHandler(t *testing.T) {
+	var result string
+	pathList := CreatePathList(recentTitle, "").
+		Load([]PathListItem{{Path: "/apples"}}).
+		SetSelectHandler(func(path string) {
+			result = path
+		})
+
+	pathList.InputHandler()(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone), nil)
+
+	if result != "/apples" {
+		t.Errorf("Expected '/apples' to be selected, got '%s' instead", result)
+	}
+}
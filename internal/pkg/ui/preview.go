@@ -0,0 +1,198 @@
+package ui
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/preview"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/utils"
+	"github.com/rivo/tview"
+	"io/fs"
+	"log"
+	"path/filepath"
+	"strings"
+)
+
+const filePreviewTitle = "Preview"
+
+// hexDumpSize is the number of bytes at the beginning of binary files that are displayed in
+// the hexdump.
+const hexDumpSize = 256
+
+// tokenColors maps the kinds of tokens of source code to their colors. Other tokens are
+// displayed in the color of the text.
+var tokenColors = map[preview.TokenKind]string{
+	preview.TokenKeyword: "yellow",
+	preview.TokenString:  "green",
+	preview.TokenComment: "gray",
+	preview.TokenNumber:  "fuchsia",
+}
+
+// loadFiles adds the files of the current directory that match the filter to the
+// DirectoryList, after the directories.
+func (d *DirectoryList) loadFiles() {
+	if d.filterMethod == filterMethodFuzzy && len(d.filterText) > 0 {
+		d.loadFuzzyMatches(d.dirUtil.ScanFiles, d.addFileItemWithHighlights)
+		return
+	}
+
+	filter := d.getDirectoryFilter()
+	hiddenCount, err := d.scanVisibleItems(d.dirUtil.ScanFiles, d.currentDir, func(fileName string) {
+		if match, isMatch := filter.match(fileName); isMatch {
+			d.addFileItemWithHighlights(fileName, match.Positions)
+		}
+	})
+	d.hiddenCount += hiddenCount
+	if err != nil {
+		log.Print(err)
+	}
+}
+
+// addFileItemWithHighlights adds an item for the file to the DirectoryList, and highlights the
+// characters of its name at the positions. Files are displayed in the file color, and selecting
+// one selects the details pane so that its preview can be scrolled.
+func (d *DirectoryList) addFileItemWithHighlights(fileName string, positions []int) {
+	text := highlightRunes(fileName, positions)
+	if d.showIgnored && d.dirUtil.IsIgnored(filepath.Join(d.currentDir, fileName)) {
+		text = "[gray]" + text + "[-]"
+	} else {
+		text = "[" + d.appConfig.Colors.File + "]" + text + "[-]"
+	}
+
+	d.itemNames[text] = fileName
+	d.fileNames[fileName] = true
+
+	d.AddItem(text, "", 0, func() {
+		d.app.SetFocus(d.details)
+	})
+}
+
+// filePreviewLoadingText is displayed in the details pane until the preview of a file is read.
+const filePreviewLoadingText = "[gray]loading…[-]"
+
+// filePreviewJob reads the preview of a file in the background.
+type filePreviewJob struct {
+	path string
+}
+
+// getFilePreviewText returns the text that is displayed in the details pane for the file at the
+// path until its preview is read in the background, so that slow files do not block the
+// application.
+func (d *DirectoryList) getFilePreviewText(path string) string {
+	job := &filePreviewJob{path: path}
+	d.previewJob = job
+	go d.readFilePreview(job, d.appConfig.Preview.MaxSize*1024)
+
+	return filePreviewLoadingText
+}
+
+// cancelFilePreview stops waiting for the preview of a file.
+func (d *DirectoryList) cancelFilePreview() {
+	d.previewJob = nil
+}
+
+// readFilePreview reads the preview of the file of the job and displays it in the event loop
+// of the application, unless the job has been cancelled by then. It runs outside the event
+// loop, so it must not access the DirectoryList directly.
+func (d *DirectoryList) readFilePreview(job *filePreviewJob, maxSize int) {
+	text := formatFilePreview(job.path, maxSize)
+
+	d.queueUpdateDraw(func() {
+		if d.previewJob != job {
+			return
+		}
+		d.previewJob = nil
+
+		d.replaceDetailsPlaceholder(filePreviewLoadingText, text)
+	})
+}
+
+// formatFilePreview returns the preview of the file at the path for the details pane. Only the
+// beginning of the file is read. Source code is highlighted, binary files are displayed as a
+// hexdump, and files that are not regular files are described by their type.
+func formatFilePreview(path string, maxSize int) string {
+	file, err := preview.Read(path, maxSize)
+	if err != nil {
+		log.Print(err)
+		return "[red]Unable to read the file. You may have insufficient privileges.[white]"
+	}
+
+	if !file.IsRegular() {
+		return getFileTypeText(file.Mode)
+	}
+
+	var text string
+	if file.IsBinary {
+		text = getHexDumpText(file)
+	} else {
+		text = getHighlightedText(filepath.Base(path), strings.ToValidUTF8(string(file.Data), ""))
+	}
+
+	return text + getTruncatedNote(text, file)
+}
+
+// getFileTypeText returns a summary of the type of a file that is not a regular file, whose
+// contents are not previewed.
+func getFileTypeText(mode fs.FileMode) string {
+	fileType := "Irregular file"
+	switch {
+	case mode&fs.ModeNamedPipe != 0:
+		fileType = "Named pipe"
+	case mode&fs.ModeSocket != 0:
+		fileType = "Socket"
+	case mode&fs.ModeCharDevice != 0:
+		fileType = "Character device"
+	case mode&fs.ModeDevice != 0:
+		fileType = "Block device"
+	}
+
+	return fmt.Sprintf("[yellow]%v[-]\n\n[gray]Only the contents of regular files are previewed.[-]", fileType)
+}
+
+// getTruncatedNote returns a note that only the beginning of the file is displayed, on its own
+// line after the text, or an empty string if the whole file is displayed.
+func getTruncatedNote(text string, file *preview.File) string {
+	if !file.IsTruncated() {
+		return ""
+	}
+
+	var note string
+	if !strings.HasSuffix(text, "\n") {
+		note = "\n"
+	}
+
+	return note + fmt.Sprintf("[gray]... showing the first %v of %v[-]",
+		utils.FormatSize(int64(len(file.Data))),
+		utils.FormatSize(file.Size))
+}
+
+// getHexDumpText returns a summary of the binary file and a hexdump of its first bytes.
+func getHexDumpText(file *preview.File) string {
+	data := file.Data
+	if len(data) > hexDumpSize {
+		data = data[:hexDumpSize]
+	}
+
+	summary := fmt.Sprintf("[yellow]Binary file[-], %v\n\n", utils.FormatSize(file.Size))
+
+	return summary + tview.Escape(hex.Dump(data))
+}
+
+// getHighlightedText returns the text of the file with its source code highlighted in color
+// tags, or the escaped text if its language is not known.
+func getHighlightedText(fileName, text string) string {
+	tokens, ok := preview.Tokenize(fileName, text)
+	if !ok {
+		return tview.Escape(text)
+	}
+
+	var builder strings.Builder
+	for _, token := range tokens {
+		if color, exists := tokenColors[token.Kind]; exists {
+			builder.WriteString("[" + color + "]" + tview.Escape(token.Text) + "[-]")
+		} else {
+			builder.WriteString(tview.Escape(token.Text))
+		}
+	}
+
+	return builder.String()
+}
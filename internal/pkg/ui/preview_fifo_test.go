@@ -0,0 +1,29 @@
+//go:build !windows
+// +build !windows
+
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func Test_DirectoryList_setDetailsText_DescribesNamedPipeWithoutReadingIt(t *testing.T) {
+	list, updates := getDirectoryListForTest(t, directoryListFixture{
+		config: getFilePreviewConfigForTest(),
+		onDisk: true,
+	})
+	if err := syscall.Mkfifo(filepath.Join(list.currentDir, "events"), 0644); err != nil {
+		t.Skipf("Unable to create a named pipe: %v", err)
+	}
+	list.load()
+
+	list.setDetailsText("events")
+	waitForDetailsJobsForTest(t, list, updates)
+
+	if text := list.details.GetText(true); !strings.HasPrefix(text, "Named pipe") {
+		t.Errorf("Expected the type of the named pipe, got the following instead:\n%s\n", text)
+	}
+}
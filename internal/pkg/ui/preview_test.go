@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"fmt"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/config"
+	"strings"
+	"testing"
+	"time"
+)
+
+func getFilePreviewConfigForTest() *config.Config {
+	appConfig := config.Default()
+	appConfig.ShowFiles = true
+	appConfig.Preview.MaxSize = 1
+
+	return appConfig
+}
+
+func Test_DirectoryList_load_ListsFilesAfterDirectoriesWhenConfigured(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{
+		config: getFilePreviewConfigForTest(),
+		dirs:   []string{"cmd", "internal"},
+		files:  map[string]string{"go.mod": "", "main.go": ""},
+	})
+
+	expected := []string{"cmd", "internal", "go.mod", "main.go"}
+	if result := getDirectoryNamesForTest(list); fmt.Sprint(result) != fmt.Sprint(expected) {
+		t.Errorf("Expected the items %v, got %v instead", expected, result)
+	}
+
+	if text, _ := list.GetItemText(3); text != "[lightskyblue]go.mod[-]" {
+		t.Errorf("Expected files to be displayed in the file color, got '%s' instead", text)
+	}
+}
+
+func Test_DirectoryList_load_AppliesFilterToFiles(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{
+		config: getFilePreviewConfigForTest(),
+		dirs:   []string{"makefiles", "docs"},
+		files:  map[string]string{"main.go": "", "README.md": ""},
+	})
+
+	list.setFilter(filterMethodBeginsWith, "ma*")
+	list.load()
+
+	expected := []string{"makefiles", "main.go"}
+	if result := getDirectoryNamesForTest(list); fmt.Sprint(result) != fmt.Sprint(expected) {
+		t.Errorf("Expected the items %v, got %v instead", expected, result)
+	}
+}
+
+func Test_DirectoryList_setDetailsText_PreviewsHighlightedSourceFile(t *testing.T) {
+	list, updates := getDirectoryListForTest(t, directoryListFixture{
+		config: getFilePreviewConfigForTest(),
+		files:  map[string]string{"main.go": "package main // entry\n"},
+		onDisk: true,
+	})
+
+	list.setDetailsText("main.go")
+	waitForDetailsJobsForTest(t, list, updates)
+
+	expected := "[yellow]package[-] main [gray]// entry[-]\n"
+	if text := list.details.GetText(false); text != expected {
+		t.Errorf("Expected the preview '%s', got '%s' instead", expected, text)
+	}
+	if list.details.GetTitle() != filePreviewTitle {
+		t.Errorf("Expected the title '%s', got '%s' instead", filePreviewTitle, list.details.GetTitle())
+	}
+}
+
+func Test_DirectoryList_setDetailsText_PreviewsBinaryFileAsHexDump(t *testing.T) {
+	list, updates := getDirectoryListForTest(t, directoryListFixture{
+		config: getFilePreviewConfigForTest(),
+		files:  map[string]string{"app.bin": "\x7fELF\x00\x01"},
+		onDisk: true,
+	})
+
+	list.setDetailsText("app.bin")
+	waitForDetailsJobsForTest(t, list, updates)
+
+	text := list.details.GetText(true)
+	if !strings.Contains(text, "Binary file, 6 B") || !strings.Contains(text, "7f 45 4c 46 00 01") {
+		t.Errorf("Expected a hexdump summary, got the following instead:\n%s\n", text)
+	}
+}
+
+func Test_DirectoryList_setDetailsText_PreviewsOnlyBeginningOfLargeFile(t *testing.T) {
+	list, updates := getDirectoryListForTest(t, directoryListFixture{
+		config: getFilePreviewConfigForTest(),
+		files:  map[string]string{"server.log": strings.Repeat("request\n", 1000)},
+		onDisk: true,
+	})
+
+	list.setDetailsText("server.log")
+	waitForDetailsJobsForTest(t, list, updates)
+
+	text := list.details.GetText(true)
+	if strings.Count(text, "request") != 128 || !strings.HasSuffix(text, "showing the first 1.0 KB of 7.8 KB") {
+		t.Errorf("Expected the first 1 KB to be previewed, got the following instead:\n%s\n", text)
+	}
+}
+
+func Test_DirectoryList_handleRightKeyEvent_DoesNotNavigateIntoFile(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{
+		config: getFilePreviewConfigForTest(),
+		files:  map[string]string{"notes.txt": "hello"},
+	})
+	root := list.currentDir
+	list.selectItem("notes.txt")
+
+	list.handleRightKeyEvent()
+
+	if list.currentDir != root {
+		t.Errorf("Expected the current directory to remain '%s', got '%s' instead", root, list.currentDir)
+	}
+}
+
+func Test_DirectoryList_setDetailsText_DisplaysOnlyPreviewOfLastSelectedFile(t *testing.T) {
+	list, updates := getDirectoryListForTest(t, directoryListFixture{
+		config: getFilePreviewConfigForTest(),
+		files:  map[string]string{"first.txt": "first", "second.txt": "second"},
+		onDisk: true,
+	})
+
+	list.setDetailsText("first.txt")
+
+	if text := list.details.GetText(true); text != "loading…" {
+		t.Errorf("Expected the preview to be loading, got '%s' instead", text)
+	}
+
+	list.setDetailsText("second.txt")
+	waitForDetailsJobsForTest(t, list, updates)
+	for isDrained := false; !isDrained; {
+		select {
+		case update := <-updates:
+			update()
+		case <-time.After(100 * time.Millisecond):
+			isDrained = true
+		}
+	}
+
+	if text := list.details.GetText(true); text != "second" {
+		t.Errorf("Expected the preview of the last selected file, got '%s' instead", text)
+	}
+}
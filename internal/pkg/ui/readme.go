@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"fmt"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/preview"
+	"github.com/rivo/tview"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// readmeFileNames are the names of the README files that are rendered in the details pane, in
+// the order that they are looked for.
+var readmeFileNames = []string{"README.md", "README", "README.txt"}
+
+// handleToggleReadmeSelection shows or hides the README of directories above their directory
+// info in the details pane.
+func (d *DirectoryList) handleToggleReadmeSelection() {
+	d.showReadme = !d.showReadme
+
+	if d.treeMode {
+		d.setTreeDetailsText(d.tree.GetSelectedPath())
+	} else if d.GetItemCount() > 0 {
+		d.setDetailsText(d.getItemName(d.GetCurrentItem()))
+	} else {
+		d.loadDetailsForCurrentDirectory()
+	}
+}
+
+// readmeLoadingText is displayed in the details pane until the README of the directory is read.
+const readmeLoadingText = "[gray]README: loading…[-]\n\n"
+
+// readmeJob reads the README of a directory in the background.
+type readmeJob struct {
+	directory string
+}
+
+// getReadmeText returns the text that is displayed in the details pane for the README of the
+// directory until it is read in the background, or an empty string if the README is hidden.
+func (d *DirectoryList) getReadmeText(directory string) string {
+	if !d.showReadme {
+		return ""
+	}
+
+	job := &readmeJob{directory: directory}
+	d.readmeJob = job
+	go d.readReadme(job, d.appConfig.Preview.MaxSize*1024)
+
+	return readmeLoadingText
+}
+
+// cancelReadme stops waiting for the README of a directory.
+func (d *DirectoryList) cancelReadme() {
+	d.readmeJob = nil
+}
+
+// readReadme reads the README of the directory of the job and displays it in the event loop
+// of the application, unless the job has been cancelled by then. It runs outside the event
+// loop, so it must not access the DirectoryList directly.
+func (d *DirectoryList) readReadme(job *readmeJob, maxSize int) {
+	text := formatReadme(job.directory, maxSize)
+
+	d.queueUpdateDraw(func() {
+		if d.readmeJob != job {
+			return
+		}
+		d.readmeJob = nil
+
+		d.replaceDetailsPlaceholder(readmeLoadingText, text)
+	})
+}
+
+// formatReadme returns the README of the directory for the details pane, or an empty string if
+// the directory has none. Markdown is rendered, and other READMEs are displayed as plain text.
+// Only the beginning of large READMEs is read.
+func formatReadme(directory string, maxSize int) string {
+	for _, name := range readmeFileNames {
+		path := filepath.Join(directory, name)
+		if info, err := os.Stat(path); err != nil || info.IsDir() {
+			continue
+		}
+
+		file, err := preview.Read(path, maxSize)
+		if err != nil {
+			log.Print(err)
+			return ""
+		}
+		if !file.IsRegular() || file.IsBinary {
+			return ""
+		}
+
+		text := strings.ToValidUTF8(string(file.Data), "")
+		if strings.EqualFold(filepath.Ext(name), ".md") {
+			text = renderMarkdown(text)
+		} else {
+			text = tview.Escape(text)
+		}
+		text += getTruncatedNote(text, file)
+
+		return fmt.Sprintf("[gray]── %v ──[-]\n\n%v\n[gray]%v[-]\n\n",
+			name, strings.TrimRight(text, "\n"), strings.Repeat("─", len(name)+6))
+	}
+
+	return ""
+}
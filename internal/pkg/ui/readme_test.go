@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_DirectoryList_handleToggleReadmeSelection_ShowsReadmeAboveDirectoryInfo(t *testing.T) {
+	appConfig := getFilePreviewConfigForTest()
+	appConfig.Info.TotalSize = false
+	appConfig.Info.GitStatus = false
+	list, updates := getDirectoryListForTest(t, directoryListFixture{
+		config: appConfig,
+		files:  map[string]string{"README.md": "# Tools\nBuild **scripts**\n", "go.mod": ""},
+		onDisk: true,
+	})
+	list.SetCurrentItem(0)
+
+	list.handleToggleReadmeSelection()
+	waitForDetailsJobsForTest(t, list, updates)
+
+	text := list.details.GetText(false)
+	readme := strings.Index(text, "[yellow::bu]Tools[-::-]\nBuild [::b]scripts[::-]")
+	table := strings.Index(text, "go.mod")
+	if readme < 0 || table < readme {
+		t.Errorf("Expected the rendered README above the file table, got the following instead:\n%s\n", text)
+	}
+
+	list.handleToggleReadmeSelection()
+
+	if text = list.details.GetText(true); strings.Contains(text, "Tools") {
+		t.Errorf("Expected the README to be hidden, got the following instead:\n%s\n", text)
+	}
+}
+
+func Test_DirectoryList_getReadmeText_ReadsReadmeInBackground(t *testing.T) {
+	list, updates := getDirectoryListForTest(t, directoryListFixture{
+		config: getFilePreviewConfigForTest(),
+		files:  map[string]string{"README.md": "# Tools\n"},
+		onDisk: true,
+	})
+	list.showReadme = true
+	list.details.SetText("before\n" + list.getReadmeText(list.currentDir) + "after")
+
+	if text := list.details.GetText(false); !strings.Contains(text, readmeLoadingText) {
+		t.Fatalf("Expected the README to be loading, got the following instead:\n%s\n", text)
+	}
+
+	waitForDetailsJobsForTest(t, list, updates)
+
+	if text := list.details.GetText(false); !strings.Contains(text, "[yellow::bu]Tools[-::-]") || strings.Contains(text, readmeLoadingText) {
+		t.Errorf("Expected the rendered README in place of the placeholder, got the following instead:\n%s\n", text)
+	}
+}
+
+func Test_formatReadme_DisplaysPlainReadmeAsText(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{
+		config: getFilePreviewConfigForTest(),
+		files:  map[string]string{"README.txt": "# [not] markdown\n"},
+		onDisk: true,
+	})
+
+	text := formatReadme(list.currentDir, 1024)
+
+	if !strings.Contains(text, "README.txt") || !strings.Contains(text, "# [not[] markdown") {
+		t.Errorf("Expected the escaped plain README, got the following instead:\n%s\n", text)
+	}
+}
+
+func Test_formatReadme_ReturnsEmptyStringWithoutReadme(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{
+		config: getFilePreviewConfigForTest(),
+		files:  map[string]string{"main.go": ""},
+		onDisk: true,
+	})
+
+	if text := formatReadme(list.currentDir, 1024); text != "" {
+		t.Errorf("Expected no README, got the following instead:\n%s\n", text)
+	}
+}
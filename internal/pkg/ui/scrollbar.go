@@ -14,6 +14,11 @@ const (
 	horizontalThumbBlur = tview.BoxDrawingsLightHorizontal
 )
 
+var (
+	scrollBarColor   = tcell.ColorLightGray
+	scrollThumbColor = tcell.ColorYellow
+)
+
 // Scrollable represents a tview Primitive that has the GetInnerRect function. The
 // GetInnerRect function is crucial for calculations that draw scroll bars on
 // Primitive components.
@@ -80,8 +85,8 @@ func GetScrollBarDrawFunc(
 			hThumbScroll = 1
 		}
 
-		scrollBarStyle := tcell.StyleDefault.Foreground(tcell.ColorLightGray)
-		thumbStyle := tcell.StyleDefault.Foreground(tcell.ColorYellow)
+		scrollBarStyle := tcell.StyleDefault.Foreground(scrollBarColor)
+		thumbStyle := tcell.StyleDefault.Foreground(scrollThumbColor)
 
 		var vThumbRune, hThumbRune rune
 		if s.HasFocus() {
@@ -0,0 +1,185 @@
+package ui
+
+import (
+	"fmt"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/dirctrl"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/fuzzy"
+	"log"
+	"path/filepath"
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// searchBatchInterval is how long search results are collected before they are added to
+	// the DirectoryList, which keeps the list from being redrawn for every result.
+	searchBatchInterval = 50 * time.Millisecond
+	// maxSearchResults is the number of results after which a search stops.
+	maxSearchResults = 1000
+)
+
+// directorySearch is a search of the descendants of the current directory that runs in the
+// background. Closing stop cancels the search.
+type directorySearch struct {
+	stop chan struct{}
+	done bool
+}
+
+// searchResult is a descendant of the current directory that matches the search query. Its
+// path is relative to the current directory.
+type searchResult struct {
+	path  string
+	match fuzzy.Match
+}
+
+// handleSearchSelection starts searching the descendants of the current directory as the
+// query is typed, which works like the live filter but lists matching directories at any
+// depth by their path relative to the current directory.
+func (d *DirectoryList) handleSearchSelection() {
+	if d.treeMode {
+		d.handleToggleTreeSelection()
+	}
+
+	d.stopLiveFilter()
+	d.liveFilter = true
+	d.searching = true
+	d.applyLiveFilter()
+}
+
+// startSearch cancels the running search and searches the descendants of the current
+// directory for the names that match the query, with the filter method that is selected in
+// the filter dialog. The immediate children are listed while the query is empty.
+func (d *DirectoryList) startSearch() {
+	d.cancelSearch()
+
+	filterMethod := d.filter.GetFilterMethod()
+	d.ignoreCase = d.filter.IsCaseInsensitive()
+	d.setFilter(filterMethod, formatFilterText(filterMethod, d.liveQuery))
+
+	if d.liveQuery != "" && d.filterErr == nil {
+		d.search = &directorySearch{stop: make(chan struct{})}
+		go d.runSearch(d.search, d.currentDir, dirctrl.SearchOptions{
+			MaxDepth:    d.appConfig.Search.MaxDepth,
+			ShowHidden:  d.showHidden,
+			ShowIgnored: d.showIgnored,
+		}, d.getDirectoryFilter())
+	}
+
+	d.load()
+	d.loadDetailsForCurrentDirectory()
+}
+
+// cancelSearch stops the running search and discards its results.
+func (d *DirectoryList) cancelSearch() {
+	if d.search != nil {
+		close(d.search.stop)
+		d.search = nil
+	}
+
+	d.searchResults = nil
+}
+
+// runSearch walks the descendants of the directory with the options and delivers the ones
+// whose names match the filter to the DirectoryList in batches. It runs outside the event
+// loop of the application, so it must not access the DirectoryList directly.
+func (d *DirectoryList) runSearch(
+	search *directorySearch,
+	directory string,
+	options dirctrl.SearchOptions,
+	filter directoryFilter,
+) {
+	var (
+		results      []searchResult
+		count        int
+		lastDelivery = time.Now()
+	)
+
+	err := d.dirUtil.SearchDirectory(directory, options, func(relativePath string) bool {
+		select {
+		case <-search.stop:
+			return false
+		default:
+		}
+
+		if match, isMatch := filter.match(filepath.Base(relativePath)); isMatch {
+			results = append(results, searchResult{relativePath, match})
+			count++
+		}
+
+		if len(results) > 0 && time.Since(lastDelivery) >= searchBatchInterval {
+			d.deliverSearchResults(search, results, false, nil)
+			results, lastDelivery = nil, time.Now()
+		}
+
+		return count < maxSearchResults
+	})
+
+	d.deliverSearchResults(search, results, true, err)
+}
+
+// deliverSearchResults adds the results of the search to the DirectoryList in the event loop
+// of the application. Results of a search that has been cancelled are discarded.
+func (d *DirectoryList) deliverSearchResults(search *directorySearch, results []searchResult, done bool, err error) {
+	d.queueUpdateDraw(func() {
+		if d.search != search {
+			return
+		}
+
+		if err != nil {
+			log.Print(err)
+		}
+
+		search.done = done
+		d.searchResults = append(d.searchResults, results...)
+
+		selectedItem := d.getItemName(d.GetCurrentItem())
+		d.load()
+
+		if !d.isMenuItem(selectedItem) && d.selectItem(selectedItem) {
+			return
+		}
+		if d.GetItemCount() > 1 && !d.isMenuItem(d.getItemName(1)) {
+			d.SetCurrentItem(1)
+			d.setDetailsText(d.getItemName(1))
+		}
+	})
+}
+
+// loadSearchResults adds the results of the search to the DirectoryList. Fuzzy matches are
+// ordered from the best to the worst match, and other results in the order they were found.
+func (d *DirectoryList) loadSearchResults() {
+	results := d.searchResults
+	if d.filterMethod == filterMethodFuzzy {
+		results = append([]searchResult{}, results...)
+		sort.SliceStable(results, func(i, j int) bool {
+			if results[i].match.Score == results[j].match.Score {
+				return len(results[i].path) < len(results[j].path)
+			}
+			return results[i].match.Score > results[j].match.Score
+		})
+	}
+
+	for _, result := range results {
+		// Note: The name of the directory is matched, so the highlights are offset by the
+		//  length of the path of its parent.
+		offset := utf8.RuneCountInString(result.path) - utf8.RuneCountInString(filepath.Base(result.path))
+		var positions []int
+		for _, position := range result.match.Positions {
+			positions = append(positions, position+offset)
+		}
+		d.addItemWithHighlights(result.path, positions)
+	}
+}
+
+// getSearchNote returns the progress of the search for the title of the DirectoryList.
+func (d *DirectoryList) getSearchNote() string {
+	switch {
+	case d.search != nil && !d.search.done:
+		return fmt.Sprintf("searching, %d found", len(d.searchResults))
+	case len(d.searchResults) >= maxSearchResults:
+		return fmt.Sprintf("first %d found", maxSearchResults)
+	default:
+		return fmt.Sprintf("%d found", len(d.searchResults))
+	}
+}
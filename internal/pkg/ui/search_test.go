@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"fmt"
+	"github.com/gdamore/tcell/v2"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/config"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/dirctrl"
+	"github.com/goldenpathtechnologies/ci/testdata/mock"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func waitForSearchForTest(t *testing.T, list *DirectoryList, updates chan func()) {
+	for list.search != nil && !list.search.done {
+		select {
+		case update := <-updates:
+			update()
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for the search to finish")
+		}
+	}
+}
+
+func getSlashedDirectoryNamesForTest(list *DirectoryList) []string {
+	var names []string
+	for _, name := range getDirectoryNamesForTest(list) {
+		names = append(names, filepath.ToSlash(name))
+	}
+
+	return names
+}
+
+func Test_DirectoryList_handleSearchSelection_ListsMatchingDescendantsAsRelativePaths(t *testing.T) {
+	list, updates := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"services/api/internal", "web/api", "docs"}})
+	list.handleSearchSelection()
+
+	typeLiveFilterForTest(list, "api")
+	waitForSearchForTest(t, list, updates)
+
+	expected := []string{"services/api", "web/api"}
+	if result := getSlashedDirectoryNamesForTest(list); fmt.Sprint(result) != fmt.Sprint(expected) {
+		t.Errorf("Expected the directories %v, got %v instead", expected, result)
+	}
+
+	if list.GetCurrentItem() != 1 {
+		t.Errorf("Expected the first result to be selected, got item %d instead", list.GetCurrentItem())
+	}
+
+	expectedTitle := fmt.Sprintf("%v - Search: %v_ (2 found)", listTitle, "api")
+	if list.GetTitle() != expectedTitle {
+		t.Errorf("Expected the title to be '%s', got '%s' instead", expectedTitle, list.GetTitle())
+	}
+}
+
+func Test_DirectoryList_handleSearchSelection_HighlightsNameOfMatchingDirectory(t *testing.T) {
+	list, updates := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"services/api"}})
+	list.filter.SetFilterMethod(config.FilterMethodFuzzy)
+	list.handleSearchSelection()
+
+	typeLiveFilterForTest(list, "ai")
+	waitForSearchForTest(t, list, updates)
+
+	text, _ := list.GetItemText(1)
+	expected := "services" + string(os.PathSeparator) + "[::bu]a[::-]p[::bu]i[::-]"
+	if text != expected {
+		t.Errorf("Expected the item text '%s', got '%s' instead", expected, text)
+	}
+}
+
+func Test_DirectoryList_handleRightKeyEvent_NavigatesToSearchResult(t *testing.T) {
+	list, updates := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"services/api/internal"}})
+	root := list.currentDir
+	list.handleSearchSelection()
+	typeLiveFilterForTest(list, "internal")
+	waitForSearchForTest(t, list, updates)
+
+	list.handleRightKeyEvent()
+
+	expected := filepath.Join(root, "services", "api", "internal")
+	if list.currentDir != expected {
+		t.Errorf("Expected the current directory to be '%s', got '%s' instead", expected, list.currentDir)
+	}
+	if list.search != nil || len(list.searchResults) > 0 {
+		t.Error("Expected the search to be cancelled")
+	}
+}
+
+func Test_DirectoryList_handleLiveFilterInput_EscCancelsSearch(t *testing.T) {
+	list, updates := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"services/api", "docs"}})
+	list.handleSearchSelection()
+	typeLiveFilterForTest(list, "api")
+
+	list.handleInputCapture(tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone))
+
+	// Note: Results that were delivered before the search was cancelled are discarded.
+	for len(updates) > 0 {
+		(<-updates)()
+	}
+
+	if list.searching || list.search != nil {
+		t.Error("Expected the search to stop")
+	}
+	if list.GetTitle() != listTitle {
+		t.Errorf("Expected the title to be '%s', got '%s' instead", listTitle, list.GetTitle())
+	}
+	if names := getDirectoryNamesForTest(list); fmt.Sprint(names) != fmt.Sprint([]string{"docs", "services"}) {
+		t.Errorf("Expected the directories [docs services], got %v instead", names)
+	}
+}
+
+func Test_DirectoryList_handleToggleHiddenSelection_DoesNotAffectRunningSearch(t *testing.T) {
+	list, updates := getDirectoryListForTest(t, directoryListFixture{dirs: []string{".cache/api", "web/api"}})
+	commands := list.dirUtil.(*dirctrl.DefaultDirectoryController).Commands.(*mock.DirectoryCommands)
+	walk, toggled := commands.WalkDirectoryFunc, make(chan struct{})
+	commands.WalkDirectoryFunc = func(root string, maxDepth int, callback func(relativePath string) error) error {
+		<-toggled
+		return walk(root, maxDepth, callback)
+	}
+	list.handleSearchSelection()
+	typeLiveFilterForTest(list, "api")
+
+	list.handleToggleHiddenSelection()
+	close(toggled)
+	waitForSearchForTest(t, list, updates)
+
+	expected := []string{".cache/api", "web/api"}
+	if result := getSlashedDirectoryNamesForTest(list); fmt.Sprint(result) != fmt.Sprint(expected) {
+		t.Errorf("Expected the search to keep the settings it started with, got %v instead", result)
+	}
+}
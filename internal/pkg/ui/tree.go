@@ -0,0 +1,382 @@
+package ui
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/dirctrl"
+	"github.com/rivo/tview"
+	"log"
+	"path/filepath"
+)
+
+// TreeEntry is a directory that is displayed in a DirectoryTree. The text may contain color
+// tags, and entries that do not match the filter are only displayed while they are expanded.
+type TreeEntry struct {
+	Path    string
+	Text    string
+	IsMatch bool
+}
+
+// DirectoryTree displays the directories below a root directory as a tree whose nodes expand
+// in place. The children of a node are loaded when it is first expanded.
+type DirectoryTree struct {
+	*tview.TreeView
+	expanded      map[string]bool
+	selectedColor string
+	loadHandler   func(path string) []TreeEntry
+	changeHandler func(path string)
+}
+
+// treeNodeReference is the reference of a node of a DirectoryTree.
+type treeNodeReference struct {
+	path string
+	text string
+}
+
+// CreateDirectoryTree creates a new instance of DirectoryTree.
+func CreateDirectoryTree() *DirectoryTree {
+	tree := &DirectoryTree{
+		TreeView:      tview.NewTreeView(),
+		expanded:      make(map[string]bool),
+		selectedColor: "black:white",
+	}
+
+	tree.SetBorder(true).
+		SetTitle(listTitle).
+		SetBorderPadding(1, 1, 0, 1).
+		SetDrawFunc(GetScrollBarDrawFunc(
+			tree,
+			tree.handleScrollArea,
+			tree.handleScrollPosition))
+
+	return tree
+}
+
+// SetSelectedColors sets the text and background colors of the selected directory. Colors are
+// W3C color names or hex values in the #rrggbb format.
+func (t *DirectoryTree) SetSelectedColors(text, background string) *DirectoryTree {
+	t.selectedColor = text + ":" + background
+
+	return t
+}
+
+// SetLoadHandler sets the function that returns the child directories of a directory.
+func (t *DirectoryTree) SetLoadHandler(handler func(path string) []TreeEntry) *DirectoryTree {
+	t.loadHandler = handler
+
+	return t
+}
+
+// SetChangeHandler sets the function that is called with the path of the directory that
+// becomes selected.
+func (t *DirectoryTree) SetChangeHandler(handler func(path string)) *DirectoryTree {
+	t.changeHandler = handler
+	t.SetChangedFunc(func(node *tview.TreeNode) {
+		t.highlightSelectedNode()
+		handler(getTreeNodePath(node))
+	})
+
+	return t
+}
+
+// SetSelectHandler sets the function that is called with the path of the selected directory
+// when Enter is pressed.
+func (t *DirectoryTree) SetSelectHandler(handler func(path string)) *DirectoryTree {
+	t.SetSelectedFunc(func(node *tview.TreeNode) {
+		handler(getTreeNodePath(node))
+	})
+
+	return t
+}
+
+// Load displays the tree of the root directory. Directories that were expanded remain
+// expanded, and the selected directory remains selected if it is still displayed.
+func (t *DirectoryTree) Load(root string) {
+	selectedPath := t.GetSelectedPath()
+
+	rootNode := newTreeNode(root, getTreeRootText(root))
+	t.expanded[root] = true
+	t.loadChildren(rootNode)
+	t.SetRoot(rootNode)
+
+	selectedNode := rootNode
+	rootNode.Walk(func(node, _ *tview.TreeNode) bool {
+		if getTreeNodePath(node) == selectedPath {
+			selectedNode = node
+			return false
+		}
+		return true
+	})
+	t.SetCurrentNode(selectedNode)
+	t.highlightSelectedNode()
+}
+
+// loadChildren reads the child directories of the node, and those of its expanded children.
+func (t *DirectoryTree) loadChildren(node *tview.TreeNode) {
+	node.ClearChildren()
+	node.SetExpanded(true)
+
+	if t.loadHandler == nil {
+		return
+	}
+
+	for _, entry := range t.loadHandler(getTreeNodePath(node)) {
+		if !entry.IsMatch && !t.expanded[entry.Path] {
+			continue
+		}
+
+		child := newTreeNode(entry.Path, entry.Text)
+		if t.expanded[entry.Path] {
+			t.loadChildren(child)
+		} else {
+			child.SetExpanded(false)
+		}
+		node.AddChild(child)
+	}
+}
+
+// GetSelectedPath returns the path of the selected directory, or an empty string if the tree
+// is empty.
+func (t *DirectoryTree) GetSelectedPath() string {
+	return getTreeNodePath(t.GetCurrentNode())
+}
+
+// ExpandSelectedNode loads and expands the children of the selected directory, or selects its
+// first child if it is already expanded.
+func (t *DirectoryTree) ExpandSelectedNode() {
+	node := t.GetCurrentNode()
+	if node == nil {
+		return
+	}
+
+	if !node.IsExpanded() {
+		t.expanded[getTreeNodePath(node)] = true
+		t.loadChildren(node)
+	} else if children := node.GetChildren(); len(children) > 0 {
+		t.selectNode(children[0])
+	}
+}
+
+// CollapseSelectedNode collapses the selected directory, or selects its parent if it is
+// already collapsed. It returns false if the root directory is selected, since it has no
+// parent in the tree.
+func (t *DirectoryTree) CollapseSelectedNode() bool {
+	node := t.GetCurrentNode()
+	if node == nil || node == t.GetRoot() {
+		return false
+	}
+
+	if node.IsExpanded() {
+		delete(t.expanded, getTreeNodePath(node))
+		node.Collapse()
+		return true
+	}
+
+	// Note: Tree nodes do not know their parent, so it is looked up from the root.
+
+	t.GetRoot().Walk(func(child, parent *tview.TreeNode) bool {
+		if child == node {
+			t.selectNode(parent)
+			return false
+		}
+		return true
+	})
+
+	return true
+}
+
+// selectNode selects the node and reports the change of the selected directory.
+func (t *DirectoryTree) selectNode(node *tview.TreeNode) {
+	t.SetCurrentNode(node)
+	t.highlightSelectedNode()
+	if t.changeHandler != nil {
+		t.changeHandler(getTreeNodePath(node))
+	}
+}
+
+// highlightSelectedNode displays the selected node in the selected colors. The TreeView
+// highlights the selected node by swapping its text and background colors, which leaves it
+// unreadable when the background has the default color of the terminal.
+func (t *DirectoryTree) highlightSelectedNode() {
+	if t.GetRoot() == nil {
+		return
+	}
+
+	selectedNode := t.GetCurrentNode()
+	t.GetRoot().Walk(func(node, _ *tview.TreeNode) bool {
+		text := node.GetReference().(treeNodeReference).text
+		if node == selectedNode {
+			text = "[" + t.selectedColor + "]" + text
+		}
+		node.SetText(text)
+		return true
+	})
+}
+
+// handleScrollArea calculates the width and height of the area that is scrollable in the
+// DirectoryTree.
+func (t *DirectoryTree) handleScrollArea() (width, height int) {
+	_, _, treeWidth, _ := t.GetInnerRect()
+
+	return treeWidth, t.GetRowCount()
+}
+
+// handleScrollPosition calculates the current scroll position of the DirectoryTree.
+func (t *DirectoryTree) handleScrollPosition() (vScroll, hScroll int) {
+	return t.GetScrollOffset(), 0
+}
+
+// newTreeNode creates a tree node for the directory at the path with the text.
+func newTreeNode(path, text string) *tview.TreeNode {
+	return tview.NewTreeNode(text).SetReference(treeNodeReference{path, text})
+}
+
+// getTreeNodePath returns the path of the directory of the tree node.
+func getTreeNodePath(node *tview.TreeNode) string {
+	if node == nil {
+		return ""
+	}
+
+	reference, _ := node.GetReference().(treeNodeReference)
+
+	return reference.path
+}
+
+// getTreeRootText returns the text of the root node of a tree, which is the name of the root
+// directory.
+func getTreeRootText(root string) string {
+	name := filepath.Base(root)
+	if name == "." || name == dirctrl.OsPathSeparator {
+		return root
+	}
+
+	return tview.Escape(name)
+}
+
+// hasTree determines if the DirectoryList has everything it needs to switch to the tree.
+func (d *DirectoryList) hasTree() bool {
+	return d.tree != nil && d.views != nil
+}
+
+// getView returns the view that displays the directories, which is either the DirectoryList
+// or the tree.
+func (d *DirectoryList) getView() tview.Primitive {
+	if d.treeMode {
+		return d.tree
+	}
+
+	return d
+}
+
+// handleToggleTreeSelection switches between the DirectoryList and the tree. The selected
+// directory of the tree is selected in the DirectoryList when switching back, if it is one of
+// the listed directories.
+func (d *DirectoryList) handleToggleTreeSelection() {
+	if !d.hasTree() {
+		return
+	}
+
+	d.treeMode = !d.treeMode
+	if d.treeMode {
+		d.stopLiveFilter()
+		d.tree.Load(d.currentDir)
+		d.views.SwitchToPage(treeViewPage)
+		d.setTreeDetailsText(d.tree.GetSelectedPath())
+	} else {
+		selectedPath := d.tree.GetSelectedPath()
+		d.load()
+		d.views.SwitchToPage(listViewPage)
+		if filepath.Dir(selectedPath) == d.currentDir && d.selectItem(filepath.Base(selectedPath)) {
+			d.setDetailsText(filepath.Base(selectedPath))
+		} else {
+			d.loadDetailsForCurrentDirectory()
+		}
+	}
+
+	d.app.SetFocus(d.getView())
+}
+
+// handleTreeInputCapture is an event handler that processes key events for the tree. Right
+// expands the selected directory and Left collapses it, selects its parent, or navigates to
+// the parent of the current directory when the root of the tree is selected.
+func (d *DirectoryList) handleTreeInputCapture(event *tcell.EventKey) *tcell.EventKey {
+	if d.liveFilter && d.handleLiveFilterInput(event) {
+		return nil
+	}
+
+	if handler := d.getKeyBindingHandler(event, false); handler != nil {
+		handler()
+		return nil
+	}
+
+	if handler := d.getAltShortcutHandler(event); handler != nil {
+		handler()
+		return nil
+	}
+
+	if event.Modifiers()&tcell.ModAlt != 0 {
+		switch event.Key() {
+		case tcell.KeyLeft:
+			d.handleBackSelection()
+			return nil
+		case tcell.KeyRight:
+			d.handleForwardSelection()
+			return nil
+		}
+	}
+
+	switch event.Key() {
+	case tcell.KeyRight:
+		d.tree.ExpandSelectedNode()
+		return nil
+	case tcell.KeyLeft:
+		if !d.tree.CollapseSelectedNode() {
+			d.handleLeftKeyEvent()
+			d.setTreeDetailsText(d.tree.GetSelectedPath())
+		}
+		return nil
+	case tcell.KeyTab:
+		d.app.SetFocus(d.details)
+		return nil
+	}
+
+	return event
+}
+
+// getTreeEntries returns the child directories of the directory at the path for the tree.
+// The filter of the DirectoryList applies to the children of every directory in the tree.
+func (d *DirectoryList) getTreeEntries(path string) []TreeEntry {
+	filter := d.getDirectoryFilter()
+
+	var entries []TreeEntry
+	if err := d.dirUtil.ScanDirectory(path, func(dirName string) {
+		if !d.showHidden && dirctrl.IsHidden(dirName) {
+			return
+		}
+
+		childPath := filepath.Join(path, dirName)
+		match, isMatch := filter.match(dirName)
+		entries = append(entries, TreeEntry{
+			Path:    childPath,
+			Text:    d.getDirectoryText(childPath, dirName, match.Positions),
+			IsMatch: isMatch,
+		})
+	}); err != nil {
+		log.Print(err)
+	}
+
+	return entries
+}
+
+// setTreeDetailsText sets the content of the details component to the directory info of the
+// directory at the path.
+func (d *DirectoryList) setTreeDetailsText(path string) {
+	if path == d.currentDir {
+		d.loadDetailsForCurrentDirectory()
+		return
+	}
+
+	d.details.
+		Clear().
+		SetText(d.getDetailsText(path)).
+		ScrollToBeginning()
+}
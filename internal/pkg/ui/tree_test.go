@@ -0,0 +1,139 @@
+package ui
+
+import (
+	"fmt"
+	"github.com/gdamore/tcell/v2"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/config"
+	"github.com/rivo/tview"
+	"path/filepath"
+	"testing"
+)
+
+func getTreeNodeTextsForTest(tree *DirectoryTree) []string {
+	var texts []string
+	tree.GetRoot().Walk(func(node, parent *tview.TreeNode) bool {
+		if parent != nil {
+			texts = append(texts, node.GetReference().(treeNodeReference).text)
+		}
+		return parent == nil || node.IsExpanded()
+	})
+
+	return texts
+}
+
+func pressTreeKeyForTest(list *DirectoryList, key tcell.Key) {
+	list.handleTreeInputCapture(tcell.NewEventKey(key, 0, tcell.ModNone))
+}
+
+func Test_DirectoryList_Init_StartsInTreeWhenConfigured(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{layout: config.LayoutTree, dirs: []string{"api", "web"}, withTree: true})
+	root := list.currentDir
+
+	if !list.treeMode || list.getView() != list.tree {
+		t.Fatal("Expected the tree to be displayed")
+	}
+	if name, _ := list.views.GetFrontPage(); name != treeViewPage {
+		t.Errorf("Expected the tree page to be shown, got '%s' instead", name)
+	}
+	if result := getTreeNodeTextsForTest(list.tree); fmt.Sprint(result) != fmt.Sprint([]string{"api", "web"}) {
+		t.Errorf("Expected the nodes [api web], got %v instead", result)
+	}
+	if list.tree.GetSelectedPath() != root {
+		t.Errorf("Expected the root to be selected, got '%s' instead", list.tree.GetSelectedPath())
+	}
+}
+
+func Test_DirectoryList_handleTreeInputCapture_RightExpandsNodeInPlace(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{layout: config.LayoutTree, dirs: []string{"api/internal", "api/cmd", "web"}, withTree: true})
+	root := list.currentDir
+
+	pressTreeKeyForTest(list, tcell.KeyRight)
+	if list.tree.GetSelectedPath() != filepath.Join(root, "api") {
+		t.Fatalf("Expected Right on the expanded root to select its first child, got '%s' instead", list.tree.GetSelectedPath())
+	}
+
+	pressTreeKeyForTest(list, tcell.KeyRight)
+
+	expected := []string{"api", "cmd", "internal", "web"}
+	if result := getTreeNodeTextsForTest(list.tree); fmt.Sprint(result) != fmt.Sprint(expected) {
+		t.Errorf("Expected the nodes %v, got %v instead", expected, result)
+	}
+	if list.currentDir != root {
+		t.Errorf("Expected the current directory to remain '%s', got '%s' instead", root, list.currentDir)
+	}
+}
+
+func Test_DirectoryList_handleTreeInputCapture_LeftCollapsesNodeThenSelectsParent(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{layout: config.LayoutTree, dirs: []string{"api/internal/db"}, withTree: true})
+	root := list.currentDir
+	for i := 0; i < 4; i++ {
+		pressTreeKeyForTest(list, tcell.KeyRight)
+	}
+	if list.tree.GetSelectedPath() != filepath.Join(root, "api", "internal") {
+		t.Fatalf("Expected 'api/internal' to be selected, got '%s' instead", list.tree.GetSelectedPath())
+	}
+
+	pressTreeKeyForTest(list, tcell.KeyLeft)
+	if node := list.tree.GetCurrentNode(); node.IsExpanded() {
+		t.Error("Expected Left to collapse the selected node")
+	}
+
+	pressTreeKeyForTest(list, tcell.KeyLeft)
+	if list.tree.GetSelectedPath() != filepath.Join(root, "api") {
+		t.Errorf("Expected Left on a collapsed node to select its parent, got '%s' instead", list.tree.GetSelectedPath())
+	}
+}
+
+func Test_DirectoryList_handleTreeInputCapture_LeftOnRootNavigatesToParentDirectory(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{layout: config.LayoutTree, dirs: []string{"api"}, withTree: true})
+	root := list.currentDir
+
+	pressTreeKeyForTest(list, tcell.KeyLeft)
+
+	if list.currentDir != filepath.Dir(root) {
+		t.Errorf("Expected the current directory to be '%s', got '%s' instead", filepath.Dir(root), list.currentDir)
+	}
+	if list.tree.GetSelectedPath() != root {
+		t.Errorf("Expected the previous root to remain selected, got '%s' instead", list.tree.GetSelectedPath())
+	}
+}
+
+func Test_DirectoryList_getTreeEntries_AppliesFilterToEveryLoadedNode(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{layout: config.LayoutTree, dirs: []string{"api/api-v1", "api/docs", "apps", "web/api-v2"}, withTree: true})
+	pressTreeKeyForTest(list, tcell.KeyRight)
+	pressTreeKeyForTest(list, tcell.KeyRight)
+
+	list.setFilter(filterMethodBeginsWith, "ap*")
+	list.load()
+
+	// Note: Expanded nodes are kept so that the matches below them remain reachable.
+	expected := []string{"api", "api-v1", "apps"}
+	if result := getTreeNodeTextsForTest(list.tree); fmt.Sprint(result) != fmt.Sprint(expected) {
+		t.Errorf("Expected the nodes %v, got %v instead", expected, result)
+	}
+}
+
+func Test_DirectoryList_handleToggleTreeSelection_SwitchesBetweenListAndTree(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{layout: config.LayoutList, dirs: []string{"api", "web"}, withTree: true})
+	root := list.currentDir
+
+	list.handleInputCapture(tcell.NewEventKey(tcell.KeyRune, 't', tcell.ModAlt))
+	if name, _ := list.views.GetFrontPage(); name != treeViewPage || !list.tree.HasFocus() {
+		t.Fatalf("Expected the tree to be shown and focused, got page '%s' instead", name)
+	}
+
+	list.tree.GetRoot().Walk(func(node, _ *tview.TreeNode) bool {
+		if getTreeNodePath(node) == filepath.Join(root, "web") {
+			list.tree.selectNode(node)
+		}
+		return true
+	})
+
+	list.handleTreeInputCapture(tcell.NewEventKey(tcell.KeyRune, 't', tcell.ModNone))
+	if name, _ := list.views.GetFrontPage(); name != listViewPage || !list.HasFocus() {
+		t.Fatalf("Expected the list to be shown and focused, got page '%s' instead", name)
+	}
+	if name := list.getItemName(list.GetCurrentItem()); name != "web" {
+		t.Errorf("Expected the directory selected in the tree to be selected in the list, got '%s' instead", name)
+	}
+}
@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// handleTypeAhead is an event handler that selects the first directory whose name begins with
+// the characters that are typed in quick succession, and returns true if it handled the event.
+// Letters are typed ahead whenever the list shows directories, so that the actions that are
+// bound to letters are triggered with Alt instead. Other characters that are bound to actions
+// trigger those actions unless type-ahead is already in progress, or altShortcuts is set.
+func (d *DirectoryList) handleTypeAhead(event *tcell.EventKey) bool {
+	timeout := time.Duration(d.appConfig.TypeAhead.Timeout) * time.Millisecond
+	if timeout <= 0 || event.Key() != tcell.KeyRune || event.Modifiers()&(tcell.ModAlt|tcell.ModCtrl) != 0 {
+		d.typeAheadText = ""
+		return false
+	}
+
+	if time.Since(d.typeAheadTime) >= timeout {
+		d.typeAheadText = ""
+	}
+
+	if d.typeAheadText == "" {
+		if event.Rune() == ' ' || !d.typesAheadIdleRune(event.Rune()) && d.getKeyBindingHandler(event, false) != nil {
+			return false
+		}
+	}
+
+	d.typeAheadText += string(event.Rune())
+	d.typeAheadTime = time.Now()
+	d.selectTypeAheadMatch()
+
+	return true
+}
+
+// typesAheadIdleRune determines if the character starts type-ahead in place of the action
+// that is bound to it.
+func (d *DirectoryList) typesAheadIdleRune(r rune) bool {
+	return d.appConfig.TypeAhead.AltShortcuts || unicode.IsLetter(r) && d.hasDirectoryItems()
+}
+
+// hasDirectoryItems determines if the list shows any directory besides the menu items.
+func (d *DirectoryList) hasDirectoryItems() bool {
+	for i := 0; i < d.GetItemCount(); i++ {
+		if !d.isMenuItem(d.getItemName(i)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// selectTypeAheadMatch selects the first directory whose name begins with the typed
+// characters, ignoring case. The selection remains unchanged if no directory matches.
+func (d *DirectoryList) selectTypeAheadMatch() {
+	prefix := strings.ToLower(d.typeAheadText)
+	for i := 0; i < d.GetItemCount(); i++ {
+		name := d.getItemName(i)
+		if !d.isMenuItem(name) && strings.HasPrefix(strings.ToLower(name), prefix) {
+			d.SetCurrentItem(i)
+			d.setDetailsText(name)
+			return
+		}
+	}
+}
+
+// getAltShortcutHandler returns the handler of the action that is bound to the character of
+// the event without Alt, when the character is a letter or every character is typed ahead.
+func (d *DirectoryList) getAltShortcutHandler(event *tcell.EventKey) func() {
+	if event.Key() != tcell.KeyRune || event.Modifiers()&tcell.ModAlt == 0 {
+		return nil
+	}
+	if !d.appConfig.TypeAhead.AltShortcuts && !unicode.IsLetter(event.Rune()) {
+		return nil
+	}
+
+	return d.getKeyBindingHandler(tcell.NewEventKey(tcell.KeyRune, event.Rune(), event.Modifiers()&^tcell.ModAlt), false)
+}
@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"testing"
+	"time"
+)
+
+func getSelectedItemNameForTest(list *DirectoryList) string {
+	return list.getItemName(list.GetCurrentItem())
+}
+
+func Test_DirectoryList_handleTypeAhead_SelectsFirstDirectoryWithTypedPrefix(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"api", "apps", "docs"}})
+
+	typeLiveFilterForTest(list, "ap")
+	if name := getSelectedItemNameForTest(list); name != "api" {
+		t.Errorf("Expected 'api' to be selected, got '%s' instead", name)
+	}
+
+	typeLiveFilterForTest(list, "PS")
+	if name := getSelectedItemNameForTest(list); name != "apps" {
+		t.Errorf("Expected 'apps' to be selected, got '%s' instead", name)
+	}
+}
+
+func Test_DirectoryList_handleTypeAhead_ShortcutsExtendPrefixWhileTyping(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"docs", "dsl"}})
+
+	typeLiveFilterForTest(list, "ds")
+
+	if name := getSelectedItemNameForTest(list); name != "dsl" {
+		t.Errorf("Expected 'dsl' to be selected, got '%s' instead", name)
+	}
+	if list.liveFilter {
+		t.Error("Expected the live filter shortcut to be typed ahead instead")
+	}
+}
+
+func Test_DirectoryList_handleTypeAhead_TypesNamesBeginningWithBoundLetters(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"docs", "services", "src"}})
+
+	typeLiveFilterForTest(list, "src")
+
+	if name := getSelectedItemNameForTest(list); name != "src" {
+		t.Errorf("Expected 'src' to be selected, got '%s' instead", name)
+	}
+	if list.liveFilter {
+		t.Error("Expected the live filter shortcut to be typed ahead instead")
+	}
+}
+
+func Test_DirectoryList_handleTypeAhead_LettersTriggerActionsWithoutDirectories(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{})
+
+	typeLiveFilterForTest(list, "s")
+
+	if !list.liveFilter {
+		t.Error("Expected the live filter to start when the list shows no directories")
+	}
+}
+
+func Test_DirectoryList_handleInputCapture_TriggersLetterShortcutsWithAlt(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"docs", "services"}})
+
+	list.handleInputCapture(tcell.NewEventKey(tcell.KeyRune, 's', tcell.ModAlt))
+
+	if !list.liveFilter {
+		t.Error("Expected Alt+s to start the live filter")
+	}
+}
+
+func Test_DirectoryList_handleTypeAhead_ResetsAfterTimeout(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"docs", "web"}})
+
+	typeLiveFilterForTest(list, "d")
+	list.typeAheadTime = time.Now().Add(-2 * time.Second)
+	typeLiveFilterForTest(list, "w")
+
+	if name := getSelectedItemNameForTest(list); name != "web" {
+		t.Errorf("Expected 'web' to be selected, got '%s' instead", name)
+	}
+}
+
+func Test_DirectoryList_handleTypeAhead_IsDisabledWithoutTimeout(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"docs", "web"}})
+	list.appConfig.TypeAhead.Timeout = 0
+	list.load()
+	selectedItem := list.GetCurrentItem()
+
+	typeLiveFilterForTest(list, "w")
+
+	if list.GetCurrentItem() != selectedItem {
+		t.Errorf("Expected the selection to remain unchanged, got '%s' instead", getSelectedItemNameForTest(list))
+	}
+}
+
+func Test_DirectoryList_handleInputCapture_TriggersShortcutsWithAltWhenConfigured(t *testing.T) {
+	list, _ := getDirectoryListForTest(t, directoryListFixture{dirs: []string{"docs", "services"}})
+	list.appConfig.TypeAhead.AltShortcuts = true
+	list.load()
+
+	typeLiveFilterForTest(list, "s")
+	if name := getSelectedItemNameForTest(list); name != "services" || list.liveFilter {
+		t.Fatalf("Expected 's' to select 'services', got '%s' instead", name)
+	}
+
+	list.handleInputCapture(tcell.NewEventKey(tcell.KeyRune, 's', tcell.ModAlt))
+	if !list.liveFilter {
+		t.Error("Expected Alt+s to start the live filter")
+	}
+}
@@ -3,30 +3,65 @@ package ui
 
 import (
 	"github.com/gdamore/tcell/v2"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/bookmark"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/config"
 	"github.com/goldenpathtechnologies/ci/internal/pkg/dirctrl"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/frecency"
 	"github.com/goldenpathtechnologies/ci/internal/pkg/options"
 	"github.com/rivo/tview"
+	"log"
+	"sync"
+	"time"
 )
 
 // setApplicationStyles configures the default app-wide component styles.
-func setApplicationStyles() {
+func setApplicationStyles(colors config.ColorConfig) {
 	// TODO: Setting this interferes with the styles of other components such
 	//  as the List. Find a way to target styles to specific components.
 	//  Additionally, runes display horribly in PowerShell if not using
 	//  Windows Terminal. Find a way to fix this.
 	tview.Styles.PrimitiveBackgroundColor = tcell.ColorDefault
+	tview.Styles.BorderColor = config.GetColor(colors.Border)
+	tview.Styles.TitleColor = config.GetColor(colors.Title)
+	tview.Styles.PrimaryTextColor = config.GetColor(colors.Text)
+	scrollBarColor = config.GetColor(colors.ScrollBar)
+	scrollThumbColor = config.GetColor(colors.ScrollThumb)
 }
 
 // Run initializes the App's components and runs its main process loop.
 func Run(app *App, appOptions *options.AppOptions) error {
-	setApplicationStyles()
+	appConfig := appOptions.GetConfig()
+	setApplicationStyles(appConfig.Colors)
 
 	pages := tview.NewPages()
-	filter := CreateFilterForm()
+	filter := CreateFilterForm().
+		SetFilterMethod(appConfig.FilterMethod).
+		SetCaseInsensitive(appConfig.IgnoreCase)
 	details := CreateDetailsView()
 	titleBox := CreateTitleBox()
+	titleBox.SetTitleColor(config.GetColor(appConfig.Colors.AppTitle))
+	pathInput := CreatePathInput()
+	header := tview.NewPages().
+		AddPage(titleBoxPage, titleBox, true, true).
+		AddPage(pathInputPage, pathInput, true, false)
+	recent := CreatePathList(recentTitle, "No recently visited directories")
+	bookmarks := CreatePathList(bookmarksTitle, "No bookmarks, press b in the directory list to add one")
+	bookmarkForm := CreateBookmarkForm()
+	history := CreatePathList(historyTitle, "No directories visited")
+	tree := CreateDirectoryTree().
+		SetSelectedColors(appConfig.Colors.SelectedText, appConfig.Colors.SelectedBackground)
+	views := tview.NewPages()
 
+	directoryCommands := &dirctrl.DefaultDirectoryCommands{
+		Collate: appConfig.Sort.Collate,
+	}
 	directoryController := dirctrl.NewDefaultDirectoryController()
+	directoryController.Commands = directoryCommands
+	directoryController.Columns = appConfig.Info.Columns
+	directoryController.TimeFormat = appConfig.Info.TimeFormat
+	if appConfig.Ignore.Enabled {
+		directoryController.Ignore = dirctrl.NewIgnoreRules(appConfig.Ignore.Patterns)
+	}
 
 	list := CreateDirectoryList(
 		app,
@@ -35,23 +70,83 @@ func Run(app *App, appOptions *options.AppOptions) error {
 		pages,
 		details,
 		directoryController,
-		appOptions).
+		appOptions)
+
+	if store, err := frecency.NewDefaultStore(); err == nil {
+		list.SetRecentDirectories(recent, store)
+		directoryCommands.Score = getFrecencyScorer(store)
+	}
+
+	if store, err := bookmark.NewDefaultStore(); err == nil {
+		list.SetBookmarks(bookmarks, bookmarkForm, store)
+	}
+
+	views.AddPage(listViewPage, list, true, true).
+		AddPage(treeViewPage, tree, true, false)
+
+	panes := tview.NewFlex()
+	if appConfig.Layout == config.LayoutColumns {
+		parentColumn := CreateDirectoryColumn()
+		parentColumn.SetSelectedTextColor(config.GetColor(appConfig.Colors.SelectedText)).
+			SetSelectedBackgroundColor(config.GetColor(appConfig.Colors.SelectedBackground))
+		list.SetParentColumn(parentColumn)
+		panes.AddItem(parentColumn, 0, appConfig.Panes.List, false)
+	}
+	panes.AddItem(views, 0, appConfig.Panes.List, true).
+		AddItem(details, 0, appConfig.Panes.Details, false)
+
+	list.SetHistoryList(history).
+		SetTree(tree, views).
+		SetPathInput(pathInput, header).
 		Init()
 
+	list.SetSelectedTextColor(config.GetColor(appConfig.Colors.SelectedText)).
+		SetSelectedBackgroundColor(config.GetColor(appConfig.Colors.SelectedBackground))
+	for _, pathList := range []*PathList{recent, bookmarks, history} {
+		pathList.SetSelectedTextColor(config.GetColor(appConfig.Colors.SelectedText)).
+			SetSelectedBackgroundColor(config.GetColor(appConfig.Colors.SelectedBackground))
+	}
+
 	flex := tview.NewFlex().SetDirection(tview.FlexRow).
-		AddItem(titleBox, 5, 0, false).
-		AddItem(tview.NewFlex().
-			AddItem(list, 0, 1, true).
-			AddItem(details, 0, 2, false),
-			0, 1, false)
+		AddItem(header, 5, 0, false).
+		AddItem(panes, 0, 1, false)
 
 	pages.AddPage("Home", flex, true, true).
-		AddPage("Filter", CreateModal(filter, 40, 7), true, false)
+		AddPage("Filter", CreateModal(filter, 40, 11), true, false).
+		AddPage("Recent", CreateModal(recent, 80, 20), true, false).
+		AddPage("Bookmarks", CreateModal(bookmarks, 80, 20), true, false).
+		AddPage("Bookmark", CreateModal(bookmarkForm, 60, 7), true, false).
+		AddPage("History", CreateModal(history, 80, 20), true, false)
 
-	if err := app.SetRoot(pages, true).SetFocus(list).Run(); err != nil {
+	if err := app.SetRoot(pages, true).SetFocus(list.getView()).Run(); err != nil {
 		app.Stop()
 		return err
 	}
 
 	return nil
 }
+
+// getFrecencyScorer returns a function that looks up the frecency score of a directory. The
+// scores are read from the store once, when the first directory is scored.
+func getFrecencyScorer(store RecentDirectoryStore) func(path string) float64 {
+	var (
+		once   sync.Once
+		scores map[string]float64
+	)
+
+	return func(path string) float64 {
+		once.Do(func() {
+			scores = make(map[string]float64)
+			entries, err := store.Ranked()
+			if err != nil {
+				log.Printf("unable to read the frecency of recent directories: %v", err)
+			}
+			now := time.Now()
+			for _, entry := range entries {
+				scores[entry.Path] = entry.Score(now)
+			}
+		})
+
+		return scores[path]
+	}
+}
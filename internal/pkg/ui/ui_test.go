@@ -0,0 +1,20 @@
+package ui
+
+import (
+	"github.com/goldenpathtechnologies/ci/internal/pkg/frecency"
+	"testing"
+	"time"
+)
+
+func Test_getFrecencyScorer_ReturnsScoresOfRankedDirectories(t *testing.T) {
+	now := time.Now().Unix()
+	store := getRecentDirectoryStoreForTest(nil, frecency.Entry{Path: "/src/api", Count: 2, LastVisit: now})
+	score := getFrecencyScorer(store)
+
+	if result := score("/src/api"); result <= 0 {
+		t.Errorf("Expected a positive score for a visited directory, got %v instead", result)
+	}
+	if result := score("/src/web"); result != 0 {
+		t.Errorf("Expected no score for an unvisited directory, got %v instead", result)
+	}
+}
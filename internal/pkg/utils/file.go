@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	lockRetryInterval = 10 * time.Millisecond
+	lockTimeout       = 2 * time.Second
+)
+
+// LockStaleAge is the age after which a lock file is assumed to belong to a process that
+// crashed.
+const LockStaleAge = 10 * time.Second
+
+// LockFile grants this process exclusive access to the file by creating a lock file next to
+// it, and returns the function that releases the lock. Lock files that are older than
+// LockStaleAge are assumed to belong to a process that crashed, and are removed.
+func LockFile(path string) (func(), error) {
+	lockPath := path + ".lock"
+	deadline := time.Now().Add(lockTimeout)
+
+	for {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			_ = file.Close()
+			return func() {
+				_ = os.Remove(lockPath)
+			}, nil
+		}
+
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, fmt.Errorf("unable to lock '%s': %w", path, err)
+		}
+
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > LockStaleAge {
+			_ = os.Remove(lockPath)
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("unable to lock '%s', it is in use by another process", path)
+		}
+
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// WriteFileAtomic replaces the contents of the file with the data. The data is written to a
+// temporary file first so that other processes never read a partially written file.
+func WriteFileAtomic(path string, data []byte) error {
+	file, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("unable to write '%s': %w", path, err)
+	}
+	defer func() {
+		_ = os.Remove(file.Name())
+	}()
+
+	if _, err = file.Write(data); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("unable to write '%s': %w", path, err)
+	}
+
+	if err = file.Close(); err != nil {
+		return fmt.Errorf("unable to write '%s': %w", path, err)
+	}
+
+	if err = os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("unable to write '%s': %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func Test_LockFile_WaitsForOtherLockToBeReleased(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.json")
+
+	release, err := LockFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		time.Sleep(5 * lockRetryInterval)
+		release()
+	}()
+
+	secondRelease, err := LockFile(path)
+	if err != nil {
+		t.Fatalf("Expected the lock to be acquired after it was released, got '%v' instead", err)
+	}
+	secondRelease()
+}
+
+func Test_LockFile_RemovesStaleLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.json")
+
+	if err := os.WriteFile(path+".lock", nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	stale := time.Now().Add(-2 * LockStaleAge)
+	_ = os.Chtimes(path+".lock", stale, stale)
+
+	release, err := LockFile(path)
+	if err != nil {
+		t.Fatalf("Expected the stale lock to be removed, got '%v' instead", err)
+	}
+	release()
+}
+
+func Test_WriteFileAtomic_ReplacesFileContents(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.json")
+
+	if err := os.WriteFile(path, []byte("old"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := WriteFileAtomic(path, []byte("new")); err != nil {
+		t.Fatal(err)
+	}
+
+	if data, _ := os.ReadFile(path); string(data) != "new" {
+		t.Errorf("Expected the file to contain 'new', got '%s' instead", data)
+	}
+
+	if files, _ := os.ReadDir(dir); len(files) != 1 {
+		t.Errorf("Expected the temporary file to be removed, got %d files instead", len(files))
+	}
+}
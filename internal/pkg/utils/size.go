@@ -0,0 +1,24 @@
+package utils
+
+import "fmt"
+
+// FormatSize formats a number of bytes in the largest binary unit that keeps the number at or
+// above 1, with one decimal place, e.g. "1.5 KB" or "3.0 GB". Sizes below 1 KB are formatted
+// in bytes.
+func FormatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	value := float64(size) / unit
+	prefixes := "KMGTPE"
+	i := 0
+	// Note: Values that would round up to 1024.0 are formatted in the next unit instead.
+	for value >= unit-0.05 && i < len(prefixes)-1 {
+		value /= unit
+		i++
+	}
+
+	return fmt.Sprintf("%.1f %cB", value, prefixes[i])
+}
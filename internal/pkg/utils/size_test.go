@@ -0,0 +1,22 @@
+package utils
+
+import "testing"
+
+func Test_FormatSize_UsesLargestUnit(t *testing.T) {
+	cases := map[int64]string{
+		0:                  "0 B",
+		1023:               "1023 B",
+		1024:               "1.0 KB",
+		1536:               "1.5 KB",
+		5 * 1024 * 1024:    "5.0 MB",
+		3 << 30:            "3.0 GB",
+		1 << 62:            "4.0 EB",
+		1024*1024*1024 - 1: "1.0 GB",
+	}
+
+	for size, expected := range cases {
+		if result := FormatSize(size); result != expected {
+			t.Errorf("Expected %d bytes to be formatted as '%s', got '%s' instead", size, expected, result)
+		}
+	}
+}
@@ -8,22 +8,35 @@ import (
 	"path/filepath"
 )
 
-// InitFileLogging Initializes logging to a file and returns the function that closes that file
-func InitFileLogging() func() {
+// GetLogFilePath returns the location of the log file, which is in the directory of the
+// executable.
+func GetLogFilePath() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(filepath.Dir(exe), ".log"), nil
+}
+
+// InitFileLogging Initializes logging to a file and returns the function that closes that file.
+// If the log file can't be opened, logging falls back to the standard error stream and the
+// error is returned so that the caller can decide whether to continue.
+func InitFileLogging() (func(), error) {
 	var (
-		exe  string
-		err  error
-		file *os.File
+		logFile string
+		err     error
+		file    *os.File
 	)
 
-	if exe, err = os.Executable(); err != nil {
-		log.Fatal(err)
+	if logFile, err = GetLogFilePath(); err != nil {
+		log.SetOutput(os.Stderr)
+		return func() {}, fmt.Errorf("unable to determine the location of the log file: %w", err)
 	}
 
-	exeDir := filepath.Dir(exe)
-	logFile := fmt.Sprintf("%v/.log", exeDir)
 	if file, err = os.OpenFile(logFile, os.O_CREATE | os.O_APPEND | os.O_WRONLY, 0644); err != nil {
-		log.Fatal(err)
+		log.SetOutput(os.Stderr)
+		return func() {}, fmt.Errorf("unable to open the log file: %w", err)
 	}
 
 	log.SetOutput(file)
@@ -33,5 +46,5 @@ func InitFileLogging() func() {
 			log.SetOutput(os.Stdout)
 			log.Fatal(err)
 		}
-	}
+	}, nil
 }
@@ -2,18 +2,22 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/bookmark"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/config"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/dirctrl"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/doctor"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/frecency"
 	"github.com/goldenpathtechnologies/ci/internal/pkg/options"
+	"github.com/goldenpathtechnologies/ci/internal/pkg/shell"
 	"github.com/goldenpathtechnologies/ci/internal/pkg/ui"
 	"github.com/goldenpathtechnologies/ci/internal/pkg/utils"
+	"io"
 	"log"
 	"os"
 	"os/signal"
 )
 
-const (
-	exitCodeInterrupt = 2
-)
-
 var (
 	AppName      = "ci"
 	BuildVersion string
@@ -24,7 +28,9 @@ var (
 )
 
 func main() {
-	closeLogFile := utils.InitFileLogging()
+	// Note: The doctor command reports why the log file can't be opened, so a failure is only
+	//  fatal for the commands that need the log file.
+	closeLogFile, logErr := utils.InitFileLogging()
 	defer closeLogFile()
 
 	var (
@@ -45,14 +51,54 @@ func main() {
 			if fErr.ErrorCode == options.OptionErrorNormalExit {
 				os.Exit(0)
 			} else {
-				log.Fatal(fErr)
+				exitWithError(fErr)
 			}
 		} else {
 			log.Fatal(err)
 		}
 	}
 
-	app := ui.NewApp(nil, os.Stdout, os.Stderr)
+	switch appOptions.Command {
+	case options.CommandInit:
+		runInitCommand(appOptions)
+		return
+	case options.CommandDoctor:
+		runDoctorCommand(appOptions)
+		return
+	}
+
+	if logErr != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "%s: %v, run '%s doctor' for details\n", AppName, logErr, AppName)
+		os.Exit(ui.ExitCodeError)
+	}
+
+	if appOptions.Command == options.CommandRecent {
+		runRecentCommand()
+		return
+	}
+
+	outputStream, err := appOptions.OpenOutputStream()
+	if err != nil {
+		exitWithError(err)
+	}
+
+	switch appOptions.Command {
+	case options.CommandQuery:
+		runQueryCommand(appOptions, outputStream)
+		return
+	case options.CommandBookmark:
+		runBookmarkCommand(appOptions, outputStream)
+		return
+	}
+
+	// Note: The start directory is validated before the alternate screen buffer is entered
+	//  so that errors remain visible in the terminal after the program exits.
+	if appOptions.StartDirectory, err = dirctrl.NewDefaultDirectoryController().
+		GetInitialDirectory(appOptions.StartDirectory); err != nil {
+		exitWithError(err)
+	}
+
+	app := ui.NewApp(nil, outputStream, os.Stderr)
 	app.Start()
 
 	// Note: code taken and modified from https://pace.dev/blog/2020/02/17/repond-to-ctrl-c-interrupt-signals-gracefully-with-context-in-golang-by-mat-ryer.html
@@ -69,15 +115,202 @@ func main() {
 
 	go func() {
 		select {
-		case <-signalChan: // first signal, cancel context
+		case <-signalChan: // first signal, cancel context and stop the UI
 			cancel()
+			app.Stop()
 		case <-ctx.Done():
 		}
 		<-signalChan // second signal, hard exit
-		os.Exit(exitCodeInterrupt)
+		os.Exit(ui.ExitCodeInterrupted)
 	}()
 
 	if err = ui.Run(app, appOptions); err != nil {
 		app.HandleError(err, true)
 	}
+
+	// Note: The UI exits the program when a directory is selected or when the user quits, so
+	//  Run only returns when the UI is stopped by Ctrl+C or an interrupt signal.
+	app.InterruptAndExit()
+}
+
+// exitWithError prints the error to the standard error stream, logs it, and exits the program.
+func exitWithError(err error) {
+	_, _ = fmt.Fprintf(os.Stderr, "%s: %v\n", AppName, err)
+	log.Fatal(err)
+}
+
+// runInitCommand prints the shell integration code for the shell specified in the init command.
+func runInitCommand(appOptions *options.AppOptions) {
+	exe, err := os.Executable()
+	if err != nil {
+		exitWithError(err)
+	}
+
+	integration := &shell.Integration{
+		Shell:        appOptions.InitCommand.Args.Shell,
+		FunctionName: appOptions.InitCommand.FunctionName,
+		Executable:   exe,
+		ExitCodes: shell.ExitCodes{
+			Selected:    ui.ExitCodeSelected,
+			Interrupted: ui.ExitCodeInterrupted,
+		},
+	}
+
+	script, err := integration.Generate()
+	if err != nil {
+		exitWithError(err)
+	}
+
+	if _, err = os.Stdout.WriteString(script); err != nil {
+		exitWithError(err)
+	}
+}
+
+// runQueryCommand prints the directory that best matches the query fragments to the output
+// stream, or exits with an error if there is no match.
+func runQueryCommand(appOptions *options.AppOptions, outputStream io.Writer) {
+	directoryController := dirctrl.NewDefaultDirectoryController()
+
+	start, err := directoryController.GetInitialDirectory("")
+	if err != nil {
+		exitWithError(err)
+	}
+
+	match, err := directoryController.FindDirectory(start, appOptions.QueryFragments)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	if _, err = fmt.Fprintln(outputStream, match); err != nil {
+		exitWithError(err)
+	}
+}
+
+// runRecentCommand prints the recently visited directories from the highest to the lowest
+// frecency, one per line.
+func runRecentCommand() {
+	store, err := frecency.NewDefaultStore()
+	if err != nil {
+		exitWithError(err)
+	}
+
+	entries, err := store.Ranked()
+	if err != nil {
+		exitWithError(err)
+	}
+
+	for _, entry := range entries {
+		if _, err = fmt.Fprintln(os.Stdout, entry.Path); err != nil {
+			exitWithError(err)
+		}
+	}
+}
+
+// runBookmarkCommand runs the bookmark command that was selected on the command line. The
+// directory of 'bookmark go' is printed to the output stream so that the shell integration can
+// change to it.
+func runBookmarkCommand(appOptions *options.AppOptions, outputStream io.Writer) {
+	store, err := bookmark.NewDefaultStore()
+	if err != nil {
+		exitWithError(err)
+	}
+
+	var path string
+	switch appOptions.BookmarkAction {
+	case options.BookmarkActionAdd:
+		if path, err = dirctrl.NewDefaultDirectoryController().
+			GetInitialDirectory(appOptions.BookmarkAdd.Args.Path); err == nil {
+			err = store.Add(appOptions.BookmarkAdd.Args.Name, path, appOptions.BookmarkAdd.Force)
+		}
+	case options.BookmarkActionRemove:
+		err = store.Remove(appOptions.BookmarkRemove.Args.Name)
+	case options.BookmarkActionList:
+		err = store.WriteList(os.Stdout)
+	case options.BookmarkActionGo:
+		if path, err = store.Resolve(appOptions.BookmarkGo.Args.Name); err == nil {
+			_, err = fmt.Fprintln(outputStream, path)
+		}
+	case options.BookmarkActionImport:
+		err = importBookmarks(store, appOptions.BookmarkImport.Args.File)
+	case options.BookmarkActionExport:
+		err = exportBookmarks(store, appOptions.BookmarkExport.Args.File)
+	default:
+		err = fmt.Errorf("unknown bookmark command '%s'", appOptions.BookmarkAction)
+	}
+
+	if err != nil {
+		exitWithError(err)
+	}
+}
+
+// importBookmarks adds the bookmarks from the file, or from the standard input if the file is
+// empty or '-', to the store.
+func importBookmarks(store *bookmark.Store, fileName string) error {
+	input := io.Reader(os.Stdin)
+	if fileName != "" && fileName != "-" {
+		file, err := os.Open(fileName)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = file.Close()
+		}()
+		input = file
+	}
+
+	count, err := store.Import(input)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Printf("Imported %d bookmarks\n", count)
+	return err
+}
+
+// exportBookmarks writes all bookmarks of the store to the file, or to the standard output if
+// the file is empty or '-'.
+func exportBookmarks(store *bookmark.Store, fileName string) error {
+	if fileName == "" || fileName == "-" {
+		return store.Export(os.Stdout)
+	}
+
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+
+	if err = store.Export(file); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// runDoctorCommand checks the environment that ci depends on, prints a report, and exits with
+// an error code if any check fails.
+func runDoctorCommand(appOptions *options.AppOptions) {
+	env := doctor.NewEnvironment()
+
+	summary, err := doctor.Run(os.Stdout, []doctor.Check{
+		env.CheckShellFunction,
+		func() doctor.Result {
+			return doctor.CheckLogFile(utils.GetLogFilePath())
+		},
+		func() doctor.Result {
+			return doctor.CheckDataDirectory(config.DataDir())
+		},
+		env.CheckTerminal,
+		env.CheckHomeDirectory,
+		func() doctor.Result {
+			return env.CheckConfiguration(appOptions.ConfigInformation.ConfigFile)
+		},
+	})
+	if err != nil {
+		exitWithError(err)
+	}
+
+	if summary.Failures > 0 {
+		os.Exit(ui.ExitCodeError)
+	}
 }
@@ -0,0 +1,34 @@
+package mock
+
+import "github.com/goldenpathtechnologies/ci/internal/pkg/bookmark"
+
+type BookmarkStore struct {
+	Bookmarks []bookmark.Bookmark
+	Stale     map[string]bool
+}
+
+func (m *BookmarkStore) List() ([]bookmark.Bookmark, error) {
+	return m.Bookmarks, nil
+}
+
+func (m *BookmarkStore) Add(name, path string, overwrite bool) error {
+	if err := bookmark.ValidateName(name); err != nil {
+		return err
+	}
+	m.Bookmarks = append(m.Bookmarks, bookmark.Bookmark{Name: name, Path: path})
+	return nil
+}
+
+func (m *BookmarkStore) Remove(name string) error {
+	for i, b := range m.Bookmarks {
+		if b.Name == name {
+			m.Bookmarks = append(m.Bookmarks[:i], m.Bookmarks[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (m *BookmarkStore) IsStale(b bookmark.Bookmark) bool {
+	return m.Stale[b.Path]
+}
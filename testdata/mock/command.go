@@ -2,12 +2,17 @@ package mock
 
 import (
 	"io/fs"
+	"path/filepath"
+	"strings"
 )
 
 type DirectoryCommands struct {
 	ReadDirectoryFunc   func(dirname string) ([]fs.FileInfo, error)
 	GetAbsolutePathFunc func(path string) (string, error)
 	ScanDirectoryFunc   func(path string, callback func(dirName string)) error
+	WalkDirectoryFunc   func(root string, maxDepth int, callback func(relativePath string) error) error
+	SortMode            string
+	SortReverse         bool
 }
 
 func (m *DirectoryCommands) ReadDirectory(dirname string) ([]fs.FileInfo, error) {
@@ -22,6 +27,15 @@ func (m *DirectoryCommands) ScanDirectory(path string, callback func(dirName str
 	return m.ScanDirectoryFunc(path, callback)
 }
 
+func (m *DirectoryCommands) WalkDirectory(root string, maxDepth int, callback func(relativePath string) error) error {
+	return m.WalkDirectoryFunc(root, maxDepth, callback)
+}
+
+func (m *DirectoryCommands) SetSortOrder(mode string, reverse bool) {
+	m.SortMode = mode
+	m.SortReverse = reverse
+}
+
 func NewDirectoryCommandsForVirtualFileSystem(fileSystem VirtualFileSystem) *DirectoryCommands {
 	return &DirectoryCommands{
 		ReadDirectoryFunc: func(dirname string) ([]fs.FileInfo, error) {
@@ -36,9 +50,49 @@ func NewDirectoryCommandsForVirtualFileSystem(fileSystem VirtualFileSystem) *Dir
 				return err
 			}
 			for _, file := range files {
-				callback(file.Name())
+				if file.IsDir() {
+					callback(file.Name())
+				}
 			}
 			return nil
 		},
+		WalkDirectoryFunc: func(root string, maxDepth int, callback func(relativePath string) error) error {
+			return walkDirectory(fileSystem, root, "", maxDepth, callback)
+		},
+	}
+}
+
+// walkDirectory calls the callback with the path of each directory below the relative path
+// of the root, relative to the root, depth first and at most maxDepth levels deep.
+func walkDirectory(
+	fileSystem VirtualFileSystem,
+	root, relativePath string,
+	maxDepth int,
+	callback func(relativePath string) error,
+) error {
+	files, err := fileSystem.Ls(filepath.Join(root, relativePath))
+	if err != nil {
+		return nil
 	}
-}
\ No newline at end of file
+
+	for _, file := range files {
+		if !file.IsDir() {
+			continue
+		}
+
+		path := filepath.Join(relativePath, file.Name())
+		if err = callback(path); err == filepath.SkipDir {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		if strings.Count(path, string(filepath.Separator))+1 < maxDepth {
+			if err = walkDirectory(fileSystem, root, path, maxDepth, callback); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
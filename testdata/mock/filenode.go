@@ -4,6 +4,7 @@ import (
 	"errors"
 	"io/fs"
 	"math/rand"
+	"sort"
 	"strconv"
 	"time"
 )
@@ -180,3 +181,14 @@ func GetHierarchicalSeedDirectories() []*FileNode {
 		},
 	}
 }
+
+// addChild adds the child to the node, ordered by name.
+func (node *FileNode) addChild(child *FileNode) {
+	i := sort.Search(len(node.Children), func(i int) bool {
+		return node.Children[i].File.Name() >= child.File.Name()
+	})
+
+	node.Children = append(node.Children, nil)
+	copy(node.Children[i+1:], node.Children[i:])
+	node.Children[i] = child
+}
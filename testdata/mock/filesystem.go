@@ -182,4 +182,68 @@ func NormalizePath(path string) string {
 		return path
 	}
 
-}
\ No newline at end of file
+}
+// NewMockFileSystemWithPaths creates a FileSystem that contains only the directories at the
+// slash separated paths and their parents, ordered by name.
+func NewMockFileSystemWithPaths(paths ...string) *FileSystem {
+	fileSystem := NewMockFileSystem(nil, 0, 0)
+	for _, path := range paths {
+		fileSystem.AddDirectory(path)
+	}
+
+	return fileSystem
+}
+
+// AddDirectory adds the directory at the slash separated path, and any missing parents, to
+// the FileSystem, and returns its node.
+func (m *FileSystem) AddDirectory(path string) *FileNode {
+	node := m.rootNode
+	for _, name := range strings.Split(strings.Trim(path, "/"), "/") {
+		if name == "" {
+			continue
+		}
+
+		child, err := findNode(name, node.Children)
+		if err != nil {
+			child = &FileNode{
+				File: File{
+					FileName:    name,
+					FileMode:    fs.ModeDir | fs.ModePerm,
+					FileModTime: time.Now(),
+				},
+				Parent: node,
+			}
+			node.addChild(child)
+		}
+		node = child
+	}
+
+	return node
+}
+
+// AddFile adds a file of the size at the slash separated path, and any missing parents, to the
+// FileSystem, and returns its node.
+func (m *FileSystem) AddFile(path string, size int64) *FileNode {
+	dir, name := "", strings.Trim(path, "/")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		dir, name = name[:i], name[i+1:]
+	}
+
+	parent := m.AddDirectory(dir)
+	if node, err := findNode(name, parent.Children); err == nil {
+		return node
+	}
+
+	node := &FileNode{
+		File: File{
+			FileName:    name,
+			FileSize:    size,
+			FileMode:    fs.ModePerm,
+			FileModTime: time.Now(),
+		},
+		Parent: parent,
+	}
+	parent.addChild(node)
+
+	return node
+}
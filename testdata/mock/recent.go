@@ -0,0 +1,16 @@
+package mock
+
+import "github.com/goldenpathtechnologies/ci/internal/pkg/frecency"
+
+type RecentDirectoryStore struct {
+	AddFunc    func(directory string) error
+	RankedFunc func() ([]frecency.Entry, error)
+}
+
+func (m *RecentDirectoryStore) Add(directory string) error {
+	return m.AddFunc(directory)
+}
+
+func (m *RecentDirectoryStore) Ranked() ([]frecency.Entry, error) {
+	return m.RankedFunc()
+}